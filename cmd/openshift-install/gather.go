@@ -30,6 +30,7 @@ import (
 	_ "github.com/openshift/installer/pkg/gather/aws"
 	_ "github.com/openshift/installer/pkg/gather/azure"
 	_ "github.com/openshift/installer/pkg/gather/gcp"
+	_ "github.com/openshift/installer/pkg/gather/ibmcloud"
 )
 
 func newGatherCmd() *cobra.Command {
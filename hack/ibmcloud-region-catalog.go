@@ -0,0 +1,98 @@
+//go:build ignore
+// +build ignore
+
+// This script regenerates the IBM Cloud region catalog used when the IBM Cloud
+// APIs cannot be reached while creating the install config. It requires an API
+// key in IC_API_KEY and is run with go generate from pkg/types/ibmcloud.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"text/template"
+	"time"
+
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/validation"
+)
+
+var catalogTemplate = template.Must(template.New("catalog").Parse(`// Code generated by hack/ibmcloud-region-catalog.go. DO NOT EDIT.
+
+package ibmcloud
+
+// RegionCatalog holds the VPC zones and instance profiles of the supported IBM
+// Cloud regions.
+var RegionCatalog = map[string]RegionCatalogEntry{
+{{- range $region, $entry := . }}
+	"{{ $region }}": {
+		Zones: []string{
+{{- range $entry.Zones }}
+			"{{ . }}",
+{{- end }}
+		},
+		Profiles: []string{
+{{- range $entry.Profiles }}
+			"{{ . }}",
+{{- end }}
+		},
+	},
+{{- end }}
+}
+`))
+
+func run(output string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, err := icibmcloud.NewClient()
+	if err != nil {
+		return err
+	}
+
+	catalog := map[string]ibmcloud.RegionCatalogEntry{}
+	for region := range validation.Regions {
+		zones, err := client.GetVPCZonesForRegion(ctx, region)
+		if err != nil {
+			return fmt.Errorf("failed to list zones of region %s: %w", region, err)
+		}
+		sort.Strings(zones)
+
+		profiles, err := client.GetVSIProfiles(ctx, region)
+		if err != nil {
+			return fmt.Errorf("failed to list instance profiles of region %s: %w", region, err)
+		}
+		profileNames := make([]string, 0, len(profiles))
+		for _, profile := range profiles {
+			profileNames = append(profileNames, *profile.Name)
+		}
+		sort.Strings(profileNames)
+
+		catalog[region] = ibmcloud.RegionCatalogEntry{Zones: zones, Profiles: profileNames}
+	}
+
+	var buf bytes.Buffer
+	if err := catalogTemplate.Execute(&buf, catalog); err != nil {
+		return err
+	}
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, data, 0644)
+}
+
+func main() {
+	output := flag.String("output", "regioncatalog_generated.go", "the file to write the catalog to")
+	flag.Parse()
+
+	if err := run(*output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
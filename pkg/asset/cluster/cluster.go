@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/cluster/azure"
+	"github.com/openshift/installer/pkg/asset/cluster/ibmcloud"
 	"github.com/openshift/installer/pkg/asset/cluster/openstack"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/password"
@@ -23,6 +24,7 @@ import (
 	platformstages "github.com/openshift/installer/pkg/terraform/stages/platform"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	typesazure "github.com/openshift/installer/pkg/types/azure"
+	typesibmcloud "github.com/openshift/installer/pkg/types/ibmcloud"
 	typesopenstack "github.com/openshift/installer/pkg/types/openstack"
 )
 
@@ -117,6 +119,10 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
 			return err
 		}
+	case typesibmcloud.Name:
+		if err := ibmcloud.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+			return err
+		}
 	case typesopenstack.Name:
 		if err := openstack.PreTerraform(); err != nil {
 			return err
@@ -137,6 +143,17 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		c.FileList = append(c.FileList, outputs)
 	}
 
+	if platform == typesibmcloud.Name {
+		// The cluster has been created, so failing to write the outputs
+		// should not fail the install.
+		outputs, err := ibmcloud.PostTerraform(context.TODO(), clusterID.InfraID, installConfig)
+		if err != nil {
+			logrus.Warnf("Failed to write %s: %v", ibmcloud.OutputsFilename, err)
+		} else {
+			c.FileList = append(c.FileList, outputs)
+		}
+	}
+
 	return nil
 }
 
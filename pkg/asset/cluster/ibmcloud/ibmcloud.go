@@ -12,12 +12,15 @@ import (
 // Metadata converts an install configuration to IBM Cloud metadata.
 func Metadata(infraID string, config *types.InstallConfig, meta *icibmcloud.Metadata) *ibmcloud.Metadata {
 	accountID, _ := meta.AccountID(context.TODO())
-	cisCrn, _ := meta.CISInstanceCRN(context.TODO())
-	dnsInstance, _ := meta.DNSInstance(context.TODO())
 
-	var dnsInstanceID string
-	if dnsInstance != nil {
-		dnsInstanceID = dnsInstance.ID
+	// With user-provisioned DNS there are no CIS or DNS Services records for
+	// the destroy to remove.
+	var cisCrn, dnsInstanceID string
+	if !config.Platform.IBMCloud.IsUserProvisionedDNS() {
+		cisCrn, _ = meta.CISInstanceCRN(context.TODO())
+		if dnsInstance, _ := meta.DNSInstance(context.TODO()); dnsInstance != nil {
+			dnsInstanceID = dnsInstance.ID
+		}
 	}
 
 	subnets := []string{}
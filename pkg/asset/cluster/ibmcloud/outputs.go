@@ -0,0 +1,185 @@
+package ibmcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
+)
+
+// OutputsFilename is the name of the file in the install directory holding
+// the IDs of the infrastructure resources of the cluster.
+const OutputsFilename = "ibmcloud-outputs.json"
+
+// Outputs are the IDs of the infrastructure resources of the cluster, for
+// automation which needs them after the install.
+type Outputs struct {
+	VPCID            string               `json:"vpcID"`
+	Subnets          map[string][]string  `json:"subnets"`
+	SecurityGroupIDs []string             `json:"securityGroupIDs"`
+	LoadBalancers    []LoadBalancerOutput `json:"loadBalancers"`
+	ImageID          string               `json:"imageID,omitempty"`
+}
+
+// LoadBalancerOutput identifies a load balancer of the cluster.
+type LoadBalancerOutput struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Hostname string `json:"hostname"`
+	Public   bool   `json:"public"`
+}
+
+// PostTerraform returns the outputs file of the cluster once Terraform has
+// created the infrastructure.
+func PostTerraform(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig) (*asset.File, error) {
+	client, err := installConfig.IBMCloud.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := collectOutputs(ctx, client, infraID, installConfig.Config.Platform.IBMCloud)
+	if err != nil {
+		return nil, err
+	}
+
+	if installConfig.Config.Platform.IBMCloud.IsUserProvisionedDNS() {
+		logUserProvisionedDNSRecords(installConfig.Config.ClusterDomain(), installConfig.Config.Publish, outputs.LoadBalancers)
+	}
+
+	data, err := json.MarshalIndent(outputs, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal ibmcloud outputs")
+	}
+	return &asset.File{Filename: OutputsFilename, Data: data}, nil
+}
+
+func collectOutputs(ctx context.Context, client icibmcloud.API, infraID string, platform *ibmcloud.Platform) (*Outputs, error) {
+	prefix := fmt.Sprintf("%s-", infraID)
+	outputs := &Outputs{
+		Subnets:          map[string][]string{},
+		SecurityGroupIDs: []string{},
+		LoadBalancers:    []LoadBalancerOutput{},
+	}
+
+	vpcName := platform.GetVPCName()
+	if vpcName == "" {
+		vpcName = names.VPCName(infraID)
+	}
+	vpcs, err := client.GetVPCs(ctx, platform.Region)
+	if err != nil {
+		return nil, err
+	}
+	for _, vpc := range vpcs {
+		if *vpc.Name == vpcName {
+			outputs.VPCID = *vpc.ID
+			break
+		}
+	}
+	if outputs.VPCID == "" {
+		return nil, errors.Errorf("vpc %s not found", vpcName)
+	}
+
+	// Subnets and security groups provided in the install config belong to the
+	// cluster as well as those the installer created.
+	userSubnets := sets.NewString(ibmcloud.SubnetNames(platform.ControlPlaneSubnets)...).Insert(ibmcloud.SubnetNames(platform.ComputeSubnets)...)
+	subnets, err := client.GetSubnets(ctx, platform.Region)
+	if err != nil {
+		return nil, err
+	}
+	for _, subnet := range subnets {
+		if *subnet.VPC.ID != outputs.VPCID {
+			continue
+		}
+		if strings.HasPrefix(*subnet.Name, prefix) || userSubnets.Has(*subnet.Name) {
+			outputs.Subnets[*subnet.Zone.Name] = append(outputs.Subnets[*subnet.Zone.Name], *subnet.ID)
+		}
+	}
+
+	userSecurityGroups := sets.NewString()
+	if sgs := platform.SecurityGroups; sgs != nil {
+		userSecurityGroups.Insert(sgs.ControlPlane...).Insert(sgs.Compute...).Insert(sgs.LoadBalancer...)
+	}
+	securityGroups, err := client.GetSecurityGroups(ctx, platform.Region, outputs.VPCID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sg := range securityGroups {
+		if strings.HasPrefix(*sg.Name, prefix) || userSecurityGroups.Has(*sg.Name) {
+			outputs.SecurityGroupIDs = append(outputs.SecurityGroupIDs, *sg.ID)
+		}
+	}
+
+	loadBalancers, err := client.GetLoadBalancers(ctx, platform.Region)
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range loadBalancers {
+		if !strings.HasPrefix(*lb.Name, prefix) {
+			continue
+		}
+		// The hostname is only assigned once the load balancer finishes
+		// provisioning.
+		output := LoadBalancerOutput{
+			ID:   *lb.ID,
+			Name: *lb.Name,
+		}
+		if lb.Hostname != nil {
+			output.Hostname = *lb.Hostname
+		}
+		if lb.IsPublic != nil {
+			output.Public = *lb.IsPublic
+		}
+		outputs.LoadBalancers = append(outputs.LoadBalancers, output)
+	}
+
+	image, err := client.GetImageByName(ctx, platform.Region, names.ImageName(infraID))
+	if err != nil {
+		if !errors.Is(err, &icibmcloud.VPCResourceNotFoundError{}) {
+			return nil, err
+		}
+	} else {
+		outputs.ImageID = *image.ID
+	}
+
+	return outputs, nil
+}
+
+// logUserProvisionedDNSRecords logs the DNS records the user must create for
+// the API load balancers. The *.apps record points to the ingress load
+// balancer, which is only created by the ingress operator once the cluster is
+// running.
+//
+// The load balancer hostnames are assigned by IBM Cloud when Terraform
+// creates the load balancers, after the manifests and ignition configs have
+// been written, so they cannot go into a manifests ConfigMap for in-cluster
+// DNS. They are only logged here and recorded in the outputs file.
+func logUserProvisionedDNSRecords(clusterDomain string, publish types.PublishingStrategy, loadBalancers []LoadBalancerOutput) {
+	for _, lb := range loadBalancers {
+		records := []string{fmt.Sprintf("api-int.%s", clusterDomain)}
+		switch {
+		case lb.Public:
+			records = []string{fmt.Sprintf("api.%s", clusterDomain)}
+		case publish == types.InternalPublishingStrategy:
+			records = append(records, fmt.Sprintf("api.%s", clusterDomain))
+		}
+		hostname := lb.Hostname
+		if hostname == "" {
+			hostname = "hostname not assigned yet"
+		}
+		for _, record := range records {
+			logrus.Infof("User-provisioned DNS: create a CNAME record %s pointing to load balancer %s (%s)", record, lb.Name, hostname)
+		}
+	}
+	logrus.Infof("User-provisioned DNS: create a CNAME record *.apps.%s pointing to the router-default service load balancer in the openshift-ingress namespace once it is available", clusterDomain)
+}
@@ -0,0 +1,295 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/golang/mock/gomock"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+var (
+	clusterVPCName = infraID + "-vpc"
+	clusterVPCID   = "cluster-vpc-id"
+	imageName      = infraID + "-rhcos"
+	imageID        = "cluster-image-id"
+
+	clusterVPC = vpcv1.VPC{
+		Name: &clusterVPCName,
+		ID:   &clusterVPCID,
+	}
+
+	clusterSubnet = func(name string, id string, vpc string, zone string) vpcv1.Subnet {
+		return vpcv1.Subnet{
+			Name: strPtr(name),
+			ID:   strPtr(id),
+			VPC:  &vpcv1.VPCReference{ID: strPtr(vpc)},
+			Zone: &vpcv1.ZoneReference{Name: strPtr(zone)},
+		}
+	}
+	clusterSubnets = []vpcv1.Subnet{
+		clusterSubnet(infraID+"-subnet-control-plane-us-south-1", "cp-1-id", clusterVPCID, "us-south-1"),
+		clusterSubnet(infraID+"-subnet-compute-us-south-1", "compute-1-id", clusterVPCID, "us-south-1"),
+		clusterSubnet(infraID+"-subnet-control-plane-us-south-2", "cp-2-id", clusterVPCID, "us-south-2"),
+		clusterSubnet(infraID+"-subnet-control-plane-us-south-3", "other-vpc-subnet-id", otherVPCID, "us-south-3"),
+		clusterSubnet(cpSubnetName, cpSubnetID, vpcID, "us-south-1"),
+		clusterSubnet(cmpSubnetName, cmpSubnetID, vpcID, "us-south-2"),
+		clusterSubnet("unrelated-subnet", "unrelated-subnet-id", vpcID, "us-south-3"),
+	}
+
+	securityGroup = func(name string, id string) vpcv1.SecurityGroup {
+		return vpcv1.SecurityGroup{Name: strPtr(name), ID: strPtr(id)}
+	}
+	clusterSecurityGroups = []vpcv1.SecurityGroup{
+		securityGroup(infraID+"-sg-control-plane", "cp-sg-id"),
+		securityGroup(infraID+"-sg-cluster-wide", "cluster-wide-sg-id"),
+		securityGroup("default-sg", "default-sg-id"),
+	}
+	sharedSecurityGroups = []vpcv1.SecurityGroup{
+		securityGroup("user-cp-sg", "user-cp-sg-id"),
+		securityGroup("user-compute-sg", "user-compute-sg-id"),
+		securityGroup("user-lb-sg", "user-lb-sg-id"),
+		securityGroup("default-sg", "default-sg-id"),
+	}
+
+	loadBalancer = func(name string, id string, public bool) vpcv1.LoadBalancer {
+		return vpcv1.LoadBalancer{
+			Name:     strPtr(name),
+			ID:       strPtr(id),
+			Hostname: strPtr(fmt.Sprintf("%s.lb.appdomain.cloud", id)),
+			IsPublic: &public,
+		}
+	}
+	clusterLoadBalancers = []vpcv1.LoadBalancer{
+		loadBalancer(infraID+"-kubernetes-api-public", "public-lb-id", true),
+		loadBalancer(infraID+"-kubernetes-api-private", "private-lb-id", false),
+		loadBalancer("other-lb", "other-lb-id", true),
+	}
+	clusterLoadBalancerOutputs = []LoadBalancerOutput{
+		{
+			ID:       "public-lb-id",
+			Name:     infraID + "-kubernetes-api-public",
+			Hostname: "public-lb-id.lb.appdomain.cloud",
+			Public:   true,
+		},
+		{
+			ID:       "private-lb-id",
+			Name:     infraID + "-kubernetes-api-private",
+			Hostname: "private-lb-id.lb.appdomain.cloud",
+			Public:   false,
+		},
+	}
+)
+
+func TestCollectOutputs(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform *ibmcloud.Platform
+		outputs  *Outputs
+		errorMsg string
+	}{
+		{
+			name:     "installer-created VPC",
+			platform: &ibmcloud.Platform{Region: region},
+			outputs: &Outputs{
+				VPCID: clusterVPCID,
+				Subnets: map[string][]string{
+					"us-south-1": {"cp-1-id", "compute-1-id"},
+					"us-south-2": {"cp-2-id"},
+				},
+				SecurityGroupIDs: []string{"cp-sg-id", "cluster-wide-sg-id"},
+				LoadBalancers:    clusterLoadBalancerOutputs,
+				ImageID:          imageID,
+			},
+		},
+		{
+			name: "existing VPC, subnets and security groups",
+			platform: func() *ibmcloud.Platform {
+				p := sharedVPCPlatform()
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"user-cp-sg"},
+					Compute:      []string{"user-compute-sg"},
+					LoadBalancer: []string{"user-lb-sg"},
+				}
+				return p
+			}(),
+			outputs: &Outputs{
+				VPCID: vpcID,
+				Subnets: map[string][]string{
+					"us-south-1": {cpSubnetID},
+					"us-south-2": {cmpSubnetID},
+				},
+				SecurityGroupIDs: []string{"user-cp-sg-id", "user-compute-sg-id", "user-lb-sg-id"},
+				LoadBalancers:    clusterLoadBalancerOutputs,
+				ImageID:          imageID,
+			},
+		},
+		{
+			name:     "image not found",
+			platform: &ibmcloud.Platform{Region: region},
+			outputs: &Outputs{
+				VPCID: clusterVPCID,
+				Subnets: map[string][]string{
+					"us-south-1": {"cp-1-id", "compute-1-id"},
+					"us-south-2": {"cp-2-id"},
+				},
+				SecurityGroupIDs: []string{"cp-sg-id", "cluster-wide-sg-id"},
+				LoadBalancers:    clusterLoadBalancerOutputs,
+			},
+		},
+		{
+			name:     "load balancer still provisioning",
+			platform: &ibmcloud.Platform{Region: region},
+			outputs: &Outputs{
+				VPCID: clusterVPCID,
+				Subnets: map[string][]string{
+					"us-south-1": {"cp-1-id", "compute-1-id"},
+					"us-south-2": {"cp-2-id"},
+				},
+				SecurityGroupIDs: []string{"cp-sg-id", "cluster-wide-sg-id"},
+				LoadBalancers: []LoadBalancerOutput{{
+					ID:   "private-lb-id",
+					Name: infraID + "-kubernetes-api-private",
+				}},
+				ImageID: imageID,
+			},
+		},
+		{
+			name:     "VPC not found",
+			platform: &ibmcloud.Platform{Region: region},
+			errorMsg: `^vpc valid-cluster-abc12-vpc not found$`,
+		},
+		{
+			name:     "failed to list VPCs",
+			platform: &ibmcloud.Platform{Region: region},
+			errorMsg: `failed to list VPCs`,
+		},
+		{
+			name:     "failed to list security groups",
+			platform: &ibmcloud.Platform{Region: region},
+			errorMsg: `failed to list security groups`,
+		},
+		{
+			name:     "failed to get image",
+			platform: &ibmcloud.Platform{Region: region},
+			errorMsg: `failed to get image`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: installer-created VPC
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC, clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, clusterVPCID).Return(clusterSecurityGroups, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return(clusterLoadBalancers, nil)
+	ibmcloudClient.EXPECT().GetImageByName(gomock.Any(), region, imageName).Return(&vpcv1.Image{ID: &imageID}, nil)
+
+	// Mocks: existing VPC, subnets and security groups
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC, clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, vpcID).Return(sharedSecurityGroups, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return(clusterLoadBalancers, nil)
+	ibmcloudClient.EXPECT().GetImageByName(gomock.Any(), region, imageName).Return(&vpcv1.Image{ID: &imageID}, nil)
+
+	// Mocks: image not found
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC, clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, clusterVPCID).Return(clusterSecurityGroups, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return(clusterLoadBalancers, nil)
+	ibmcloudClient.EXPECT().GetImageByName(gomock.Any(), region, imageName).Return(nil, &icibmcloud.VPCResourceNotFoundError{})
+
+	// Mocks: load balancer still provisioning
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC, clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, clusterVPCID).Return(clusterSecurityGroups, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return([]vpcv1.LoadBalancer{{
+		Name: strPtr(infraID + "-kubernetes-api-private"),
+		ID:   strPtr("private-lb-id"),
+	}}, nil)
+	ibmcloudClient.EXPECT().GetImageByName(gomock.Any(), region, imageName).Return(&vpcv1.Image{ID: &imageID}, nil)
+
+	// Mocks: VPC not found
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC}, nil)
+
+	// Mocks: failed to list VPCs
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return(nil, fmt.Errorf("failed to list VPCs"))
+
+	// Mocks: failed to list security groups
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, clusterVPCID).Return(nil, fmt.Errorf("failed to list security groups"))
+
+	// Mocks: failed to get image
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{clusterVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(clusterSubnets, nil)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, clusterVPCID).Return(clusterSecurityGroups, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return(clusterLoadBalancers, nil)
+	ibmcloudClient.EXPECT().GetImageByName(gomock.Any(), region, imageName).Return(nil, fmt.Errorf("failed to get image"))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, err := collectOutputs(context.TODO(), ibmcloudClient, infraID, tc.platform)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				assert.Nil(t, outputs)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.outputs, outputs)
+			}
+		})
+	}
+}
+
+func TestLogUserProvisionedDNSRecords(t *testing.T) {
+	cases := []struct {
+		name     string
+		publish  types.PublishingStrategy
+		infoMsgs []string
+	}{
+		{
+			name:    "external",
+			publish: types.ExternalPublishingStrategy,
+			infoMsgs: []string{
+				`create a CNAME record api\.valid-cluster\.example\.com pointing to load balancer valid-cluster-abc12-kubernetes-api-public \(public-lb-id\.lb\.appdomain\.cloud\)$`,
+				`create a CNAME record api-int\.valid-cluster\.example\.com pointing to load balancer valid-cluster-abc12-kubernetes-api-private \(private-lb-id\.lb\.appdomain\.cloud\)$`,
+				`create a CNAME record \*\.apps\.valid-cluster\.example\.com pointing to the router-default service load balancer`,
+			},
+		},
+		{
+			name:    "internal",
+			publish: types.InternalPublishingStrategy,
+			infoMsgs: []string{
+				`create a CNAME record api\.valid-cluster\.example\.com pointing to load balancer valid-cluster-abc12-kubernetes-api-public \(public-lb-id\.lb\.appdomain\.cloud\)$`,
+				`create a CNAME record api-int\.valid-cluster\.example\.com pointing to load balancer valid-cluster-abc12-kubernetes-api-private \(private-lb-id\.lb\.appdomain\.cloud\)$`,
+				`create a CNAME record api\.valid-cluster\.example\.com pointing to load balancer valid-cluster-abc12-kubernetes-api-private \(private-lb-id\.lb\.appdomain\.cloud\)$`,
+				`create a CNAME record \*\.apps\.valid-cluster\.example\.com pointing to the router-default service load balancer`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := logrusTest.NewGlobal()
+			logUserProvisionedDNSRecords("valid-cluster.example.com", tc.publish, clusterLoadBalancerOutputs)
+
+			entries := hook.AllEntries()
+			if assert.Len(t, entries, len(tc.infoMsgs)) {
+				for i, msg := range tc.infoMsgs {
+					assert.Regexp(t, msg, entries[i].Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
+)
+
+// PreTerraform performs any infrastructure initialization which must
+// happen before Terraform creates the remaining infrastructure.
+func PreTerraform(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig) error {
+	client, err := installConfig.IBMCloud.Client()
+	if err != nil {
+		return err
+	}
+	platform := installConfig.Config.Platform.IBMCloud
+	resources, err := listVPCResources(ctx, client, platform.Region)
+	if err != nil {
+		return err
+	}
+	resourceGroup, err := clusterResourceGroup(ctx, client, infraID, platform)
+	if err != nil {
+		return err
+	}
+	// A resource group that does not exist yet is created by the installer
+	// and cannot hold anything named for the cluster.
+	if resourceGroup != nil {
+		generated, err := generatedResourceNames(ctx, infraID, installConfig)
+		if err != nil {
+			return err
+		}
+		if err := checkNameCollisions(ctx, client, infraID, platform, resourceGroup, generated, resources); err != nil {
+			return err
+		}
+	}
+	if platform.VPCName != "" {
+		warnSharedVPC(ctx, client, infraID, platform, resources)
+	}
+	return nil
+}
+
+// vpcResources are the VPC resources of the region, listed once for both the
+// name collision check and the shared VPC warnings.
+type vpcResources struct {
+	vpcs          []vpcv1.VPC
+	subnets       []vpcv1.Subnet
+	loadBalancers []vpcv1.LoadBalancer
+}
+
+func listVPCResources(ctx context.Context, client icibmcloud.API, region string) (*vpcResources, error) {
+	vpcs, err := client.GetVPCs(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	subnets, err := client.GetSubnets(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	loadBalancers, err := client.GetLoadBalancers(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return &vpcResources{vpcs: vpcs, subnets: subnets, loadBalancers: loadBalancers}, nil
+}
+
+// clusterResourceGroup returns the resource group the cluster resources are
+// created in, or nil when it does not exist yet.
+func clusterResourceGroup(ctx context.Context, client icibmcloud.API, infraID string, platform *ibmcloud.Platform) (*resourcemanagerv2.ResourceGroup, error) {
+	name := platform.ResourceGroupName
+	if name == "" {
+		name = infraID
+	}
+	groups, err := client.GetResourceGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if *groups[i].Name == name || *groups[i].ID == name {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// resourceNames are the names of the VPC resources the installer creates for
+// the cluster.
+type resourceNames struct {
+	vpcs           sets.String
+	subnets        sets.String
+	loadBalancers  sets.String
+	securityGroups sets.String
+}
+
+func generatedResourceNames(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig) (*resourceNames, error) {
+	platform := installConfig.Config.Platform.IBMCloud
+	generated := &resourceNames{
+		vpcs:           sets.NewString(),
+		subnets:        sets.NewString(),
+		loadBalancers:  sets.NewString(names.APILoadBalancerName(infraID, false)),
+		securityGroups: sets.NewString(),
+	}
+	if installConfig.Config.Publish == types.ExternalPublishingStrategy {
+		generated.loadBalancers.Insert(names.APILoadBalancerName(infraID, true))
+	}
+	for _, kind := range []names.SecurityGroup{
+		names.ClusterWideSecurityGroup,
+		names.OpenShiftNetworkSecurityGroup,
+		names.ControlPlaneSecurityGroup,
+		names.ControlPlaneInternalSecurityGroup,
+	} {
+		generated.securityGroups.Insert(names.SecurityGroupName(infraID, kind))
+	}
+
+	// The VPC and its subnets are only created when no VPC is provided.
+	if platform.VPCName != "" {
+		return generated, nil
+	}
+	generated.vpcs.Insert(names.VPCName(infraID))
+
+	var regionZones []string
+	poolSubnets := func(role names.Role, poolPlatform *ibmcloud.MachinePool) error {
+		mpool := ibmcloud.MachinePool{}
+		mpool.Set(platform.DefaultMachinePlatform)
+		mpool.Set(poolPlatform)
+		zones := mpool.Zones
+		if len(zones) == 0 {
+			if regionZones == nil {
+				var err error
+				regionZones, err = installConfig.IBMCloud.VPCZones(ctx)
+				if err != nil {
+					return errors.Wrap(err, "failed to fetch availability zones")
+				}
+			}
+			zones = regionZones
+		}
+		subnets, err := names.SubnetNames(infraID, role, zones)
+		if err != nil {
+			return err
+		}
+		for _, subnet := range subnets {
+			generated.subnets.Insert(subnet)
+		}
+		return nil
+	}
+	if pool := installConfig.Config.ControlPlane; pool != nil {
+		if err := poolSubnets(names.ControlPlaneRole, pool.Platform.IBMCloud); err != nil {
+			return nil, err
+		}
+	}
+	for _, pool := range installConfig.Config.Compute {
+		if err := poolSubnets(names.ComputeRole, pool.Platform.IBMCloud); err != nil {
+			return nil, err
+		}
+	}
+	return generated, nil
+}
+
+// checkNameCollisions returns an error listing the VPC resources in the
+// resource group of the cluster that have the names the installer is about to
+// create but are not tagged for the cluster. They belong to something else and
+// would either be adopted or fail creation part way through the install.
+// Resources tagged for the cluster are left from an earlier attempt of this
+// install and are not conflicts.
+func checkNameCollisions(ctx context.Context, client icibmcloud.API, infraID string, platform *ibmcloud.Platform, resourceGroup *resourcemanagerv2.ResourceGroup, generated *resourceNames, resources *vpcResources) error {
+	type candidate struct {
+		kind, name, id, crn string
+	}
+	candidates := []candidate{}
+
+	var vpcID string
+	for _, vpc := range resources.vpcs {
+		if inResourceGroup(vpc.ResourceGroup, resourceGroup) && generated.vpcs.Has(*vpc.Name) {
+			candidates = append(candidates, candidate{kind: "vpc", name: *vpc.Name, id: *vpc.ID, crn: *vpc.CRN})
+		}
+		if platform.VPCName != "" && *vpc.Name == platform.VPCName {
+			vpcID = *vpc.ID
+		}
+	}
+	for _, subnet := range resources.subnets {
+		if inResourceGroup(subnet.ResourceGroup, resourceGroup) && generated.subnets.Has(*subnet.Name) {
+			candidates = append(candidates, candidate{kind: "subnet", name: *subnet.Name, id: *subnet.ID, crn: *subnet.CRN})
+		}
+	}
+	for _, lb := range resources.loadBalancers {
+		if inResourceGroup(lb.ResourceGroup, resourceGroup) && generated.loadBalancers.Has(*lb.Name) {
+			candidates = append(candidates, candidate{kind: "load balancer", name: *lb.Name, id: *lb.ID, crn: *lb.CRN})
+		}
+	}
+
+	// Security group names are only unique within a VPC, and the VPC the
+	// installer creates does not exist yet.
+	if vpcID != "" {
+		securityGroups, err := client.GetSecurityGroups(ctx, platform.Region, vpcID)
+		if err != nil {
+			return err
+		}
+		for _, sg := range securityGroups {
+			if inResourceGroup(sg.ResourceGroup, resourceGroup) && generated.securityGroups.Has(*sg.Name) {
+				candidates = append(candidates, candidate{kind: "security group", name: *sg.Name, id: *sg.ID, crn: *sg.CRN})
+			}
+		}
+	}
+
+	conflicts := []string{}
+	for _, c := range candidates {
+		tags, err := client.GetAttachedTags(ctx, c.crn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the tags of %s %s", c.kind, c.name)
+		}
+		if !sets.NewString(tags...).Has(names.ClusterOwnedTag(infraID)) {
+			conflicts = append(conflicts, fmt.Sprintf("%s %s (%s)", c.kind, c.name, c.id))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return errors.Errorf("resources named for cluster %s already exist in resource group %s, remove them or use a different cluster name: %s", infraID, *resourceGroup.Name, strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+func inResourceGroup(ref *vpcv1.ResourceGroupReference, resourceGroup *resourcemanagerv2.ResourceGroup) bool {
+	return ref != nil && ref.ID != nil && *ref.ID == *resourceGroup.ID
+}
+
+// warnSharedVPC logs warnings for resources of the user-provided VPC the
+// cluster shares with other clusters or workloads: load balancers of
+// others in the cluster subnets, named after their cluster when their tags
+// identify it, and subnets using the default network ACL of the VPC, whose
+// rules apply to every subnet using it. Nothing it finds prevents the
+// install, so it never fails.
+func warnSharedVPC(ctx context.Context, client icibmcloud.API, infraID string, platform *ibmcloud.Platform, resources *vpcResources) {
+	prefix := fmt.Sprintf("%s-", infraID)
+
+	var vpc *vpcv1.VPC
+	for i := range resources.vpcs {
+		if *resources.vpcs[i].Name == platform.VPCName {
+			vpc = &resources.vpcs[i]
+			break
+		}
+	}
+	if vpc == nil {
+		logrus.Warnf("Skipping the shared VPC checks, VPC %s was not found in region %s", platform.VPCName, platform.Region)
+		return
+	}
+
+	clusterSubnets := sets.NewString(ibmcloud.SubnetNames(platform.ControlPlaneSubnets)...).Insert(ibmcloud.SubnetNames(platform.ComputeSubnets)...)
+	subnetIDs := sets.NewString()
+	for _, subnet := range resources.subnets {
+		if *subnet.VPC.ID != *vpc.ID || !clusterSubnets.Has(*subnet.Name) {
+			continue
+		}
+		subnetIDs.Insert(*subnet.ID)
+		if vpc.DefaultNetworkACL != nil && subnet.NetworkACL != nil && *subnet.NetworkACL.ID == *vpc.DefaultNetworkACL.ID {
+			logrus.Warnf("Subnet %s uses the default network ACL of VPC %s, which is shared with every other subnet of the VPC using it", *subnet.Name, *vpc.Name)
+		}
+	}
+
+	for _, lb := range resources.loadBalancers {
+		if strings.HasPrefix(*lb.Name, prefix) {
+			continue
+		}
+		attached := []string{}
+		for _, subnet := range lb.Subnets {
+			if subnetIDs.Has(*subnet.ID) {
+				attached = append(attached, *subnet.Name)
+			}
+		}
+		if len(attached) == 0 {
+			continue
+		}
+
+		owner := ""
+		tags, err := client.GetAttachedTags(ctx, *lb.CRN)
+		if err != nil {
+			logrus.Debugf("Failed to get the tags of load balancer %s: %v", *lb.Name, err)
+		}
+		for _, tag := range tags {
+			if cluster, ok := names.OwningCluster(tag); ok && cluster != infraID {
+				owner = cluster
+				break
+			}
+		}
+		if owner != "" {
+			logrus.Warnf("Load balancer %s of cluster %s is already attached to subnets %s", *lb.Name, owner, strings.Join(attached, ", "))
+		} else {
+			logrus.Warnf("Load balancer %s of another workload is already attached to subnets %s", *lb.Name, strings.Join(attached, ", "))
+		}
+	}
+}
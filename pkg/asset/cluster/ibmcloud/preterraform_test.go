@@ -0,0 +1,592 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+var (
+	infraID       = "valid-cluster-abc12"
+	region        = "us-south"
+	vpcName       = "shared-vpc"
+	vpcID         = "shared-vpc-id"
+	defaultACLID  = "default-acl-id"
+	customACLID   = "custom-acl-id"
+	cpSubnetName  = "cp-subnet"
+	cpSubnetID    = "cp-subnet-id"
+	cmpSubnetName = "compute-subnet"
+	cmpSubnetID   = "compute-subnet-id"
+	otherVPCName  = "other-vpc"
+	otherVPCID    = "other-vpc-id"
+	otherLBName   = "other-lb"
+	otherLBID     = "other-lb-id"
+	otherLBCRN    = "crn:v1:bluemix:public:is:us-south:a/account::load-balancer:other-lb-id"
+	clusterRGName = "cluster-rg"
+	clusterRGID   = "cluster-rg-id"
+
+	clusterRG            = resourcemanagerv2.ResourceGroup{Name: &clusterRGName, ID: &clusterRGID}
+	infraIDResourceGroup = resourcemanagerv2.ResourceGroup{Name: &infraID, ID: strPtr("infra-id-rg-id")}
+	clusterRGRef         = &vpcv1.ResourceGroupReference{ID: &clusterRGID}
+
+	sharedVPC = vpcv1.VPC{
+		Name:              &vpcName,
+		ID:                &vpcID,
+		DefaultNetworkACL: &vpcv1.NetworkACLReference{ID: &defaultACLID},
+	}
+	otherVPC = vpcv1.VPC{
+		Name: &otherVPCName,
+		ID:   &otherVPCID,
+	}
+	cpSubnet = vpcv1.Subnet{
+		Name:       &cpSubnetName,
+		ID:         &cpSubnetID,
+		VPC:        &vpcv1.VPCReference{ID: &vpcID},
+		NetworkACL: &vpcv1.NetworkACLReference{ID: &customACLID},
+	}
+	cmpSubnet = vpcv1.Subnet{
+		Name:       &cmpSubnetName,
+		ID:         &cmpSubnetID,
+		VPC:        &vpcv1.VPCReference{ID: &vpcID},
+		NetworkACL: &vpcv1.NetworkACLReference{ID: &customACLID},
+	}
+	otherLB = vpcv1.LoadBalancer{
+		Name:    &otherLBName,
+		ID:      &otherLBID,
+		CRN:     &otherLBCRN,
+		Subnets: []vpcv1.SubnetReference{{Name: &cpSubnetName, ID: &cpSubnetID}},
+	}
+	generatedVPC = vpcv1.VPC{
+		Name:          strPtr(infraID + "-vpc"),
+		ID:            strPtr("conflict-vpc-id"),
+		CRN:           strPtr("conflict-vpc-crn"),
+		ResourceGroup: clusterRGRef,
+	}
+	generatedSubnet = vpcv1.Subnet{
+		Name:          strPtr(infraID + "-subnet-control-plane-us-south-1"),
+		ID:            strPtr("conflict-subnet-id"),
+		CRN:           strPtr("conflict-subnet-crn"),
+		ResourceGroup: clusterRGRef,
+	}
+	generatedLB = vpcv1.LoadBalancer{
+		Name:          strPtr(infraID + "-kubernetes-api-public"),
+		ID:            strPtr("conflict-lb-id"),
+		CRN:           strPtr("conflict-lb-crn"),
+		ResourceGroup: clusterRGRef,
+	}
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func sharedVPCPlatform() *ibmcloud.Platform {
+	return &ibmcloud.Platform{
+		Region:              region,
+		VPCName:             vpcName,
+		ControlPlaneSubnets: []ibmcloud.Subnet{{Name: cpSubnetName}},
+		ComputeSubnets:      []ibmcloud.Subnet{{Name: cmpSubnetName}},
+	}
+}
+
+func TestListVPCResources(t *testing.T) {
+	cases := []struct {
+		name     string
+		errorMsg string
+	}{
+		{
+			name: "all resources listed",
+		},
+		{
+			name:     "failed to list VPCs",
+			errorMsg: `failed to list VPCs`,
+		},
+		{
+			name:     "failed to list subnets",
+			errorMsg: `failed to list subnets`,
+		},
+		{
+			name:     "failed to list load balancers",
+			errorMsg: `failed to list load balancers`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: all resources listed
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return([]vpcv1.Subnet{cpSubnet}, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return([]vpcv1.LoadBalancer{otherLB}, nil)
+
+	// Mocks: failed to list VPCs
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return(nil, fmt.Errorf("failed to list VPCs"))
+
+	// Mocks: failed to list subnets
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return(nil, fmt.Errorf("failed to list subnets"))
+
+	// Mocks: failed to list load balancers
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), region).Return([]vpcv1.VPC{sharedVPC}, nil)
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), region).Return([]vpcv1.Subnet{cpSubnet}, nil)
+	ibmcloudClient.EXPECT().GetLoadBalancers(gomock.Any(), region).Return(nil, fmt.Errorf("failed to list load balancers"))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, err := listVPCResources(context.TODO(), ibmcloudClient, region)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+				assert.Nil(t, resources)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &vpcResources{
+					vpcs:          []vpcv1.VPC{sharedVPC},
+					subnets:       []vpcv1.Subnet{cpSubnet},
+					loadBalancers: []vpcv1.LoadBalancer{otherLB},
+				}, resources)
+			}
+		})
+	}
+}
+
+func TestClusterResourceGroup(t *testing.T) {
+	cases := []struct {
+		name          string
+		platform      *ibmcloud.Platform
+		resourceGroup *resourcemanagerv2.ResourceGroup
+		errorMsg      string
+	}{
+		{
+			name:          "resource group named for the cluster",
+			platform:      &ibmcloud.Platform{Region: region},
+			resourceGroup: &infraIDResourceGroup,
+		},
+		{
+			name:          "provided resource group",
+			platform:      &ibmcloud.Platform{Region: region, ResourceGroupName: clusterRGName},
+			resourceGroup: &clusterRG,
+		},
+		{
+			name:     "resource group not created yet",
+			platform: &ibmcloud.Platform{Region: region},
+		},
+		{
+			name:     "failed to list resource groups",
+			platform: &ibmcloud.Platform{Region: region},
+			errorMsg: `failed to list resource groups`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: resource group named for the cluster
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return([]resourcemanagerv2.ResourceGroup{clusterRG, infraIDResourceGroup}, nil)
+
+	// Mocks: provided resource group
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return([]resourcemanagerv2.ResourceGroup{clusterRG, infraIDResourceGroup}, nil)
+
+	// Mocks: resource group not created yet
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return([]resourcemanagerv2.ResourceGroup{clusterRG}, nil)
+
+	// Mocks: failed to list resource groups
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(nil, fmt.Errorf("failed to list resource groups"))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceGroup, err := clusterResourceGroup(context.TODO(), ibmcloudClient, infraID, tc.platform)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.resourceGroup, resourceGroup)
+			}
+		})
+	}
+}
+
+func TestGeneratedResourceNames(t *testing.T) {
+	securityGroups := sets.NewString(
+		infraID+"-sg-cluster-wide",
+		infraID+"-sg-openshift-net",
+		infraID+"-sg-control-plane",
+		infraID+"-sg-cp-internal",
+	)
+	cases := []struct {
+		name     string
+		config   *types.InstallConfig
+		expected *resourceNames
+	}{
+		{
+			name: "new VPC",
+			config: &types.InstallConfig{
+				Publish: types.ExternalPublishingStrategy,
+				Platform: types.Platform{
+					IBMCloud: &ibmcloud.Platform{Region: region},
+				},
+				ControlPlane: &types.MachinePool{
+					Platform: types.MachinePoolPlatform{
+						IBMCloud: &ibmcloud.MachinePool{Zones: []string{"us-south-1", "us-south-2"}},
+					},
+				},
+				Compute: []types.MachinePool{{
+					Platform: types.MachinePoolPlatform{
+						IBMCloud: &ibmcloud.MachinePool{Zones: []string{"us-south-3"}},
+					},
+				}},
+			},
+			expected: &resourceNames{
+				vpcs: sets.NewString(infraID + "-vpc"),
+				subnets: sets.NewString(
+					infraID+"-subnet-control-plane-us-south-1",
+					infraID+"-subnet-control-plane-us-south-2",
+					infraID+"-subnet-compute-us-south-3",
+				),
+				loadBalancers:  sets.NewString(infraID+"-kubernetes-api-public", infraID+"-kubernetes-api-private"),
+				securityGroups: securityGroups,
+			},
+		},
+		{
+			name: "internal cluster in a provided VPC",
+			config: &types.InstallConfig{
+				Publish: types.InternalPublishingStrategy,
+				Platform: types.Platform{
+					IBMCloud: sharedVPCPlatform(),
+				},
+				ControlPlane: &types.MachinePool{},
+			},
+			expected: &resourceNames{
+				vpcs:           sets.NewString(),
+				subnets:        sets.NewString(),
+				loadBalancers:  sets.NewString(infraID + "-kubernetes-api-private"),
+				securityGroups: securityGroups,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			generated, err := generatedResourceNames(context.TODO(), infraID, installconfig.MakeAsset(tc.config))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, generated)
+		})
+	}
+}
+
+func TestCheckNameCollisions(t *testing.T) {
+	cases := []struct {
+		name      string
+		platform  *ibmcloud.Platform
+		resources *vpcResources
+		errorMsg  string
+	}{
+		{
+			name:     "no conflicts",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs:          []vpcv1.VPC{otherVPC},
+				subnets:       []vpcv1.Subnet{cpSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{otherLB},
+			},
+		},
+		{
+			name:     "conflicting VPC",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{generatedVPC},
+			},
+			errorMsg: `^resources named for cluster valid-cluster-abc12 already exist in resource group cluster-rg, remove them or use a different cluster name: vpc valid-cluster-abc12-vpc \(conflict-vpc-id\)$`,
+		},
+		{
+			name:     "conflicting subnet and load balancer",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				subnets:       []vpcv1.Subnet{generatedSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{generatedLB},
+			},
+			errorMsg: `subnet valid-cluster-abc12-subnet-control-plane-us-south-1 \(conflict-subnet-id\), load balancer valid-cluster-abc12-kubernetes-api-public \(conflict-lb-id\)$`,
+		},
+		{
+			name:     "resources tagged for the cluster",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs:    []vpcv1.VPC{generatedVPC},
+				subnets: []vpcv1.Subnet{generatedSubnet},
+			},
+		},
+		{
+			name:     "resources in another resource group",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{{
+					Name:          strPtr(infraID + "-vpc"),
+					ID:            strPtr("other-rg-vpc-id"),
+					CRN:           strPtr("other-rg-vpc-crn"),
+					ResourceGroup: &vpcv1.ResourceGroupReference{ID: strPtr("other-rg-id")},
+				}},
+			},
+		},
+		{
+			name:     "names not generated for the cluster",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{{
+					Name:          strPtr(infraID + "-vpc-old"),
+					ID:            strPtr("not-a-conflict-vpc-id"),
+					CRN:           strPtr("not-a-conflict-vpc-crn"),
+					ResourceGroup: clusterRGRef,
+				}},
+			},
+		},
+		{
+			name:     "failed to get tags",
+			platform: &ibmcloud.Platform{Region: region},
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{generatedVPC},
+			},
+			errorMsg: `^failed to get the tags of vpc valid-cluster-abc12-vpc: failed to get tags$`,
+		},
+		{
+			name:     "no security group conflicts in shared VPC",
+			platform: sharedVPCPlatform(),
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{sharedVPC},
+			},
+		},
+		{
+			name:     "conflicting security group in shared VPC",
+			platform: sharedVPCPlatform(),
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{sharedVPC},
+			},
+			errorMsg: `: security group valid-cluster-abc12-sg-control-plane \(conflict-sg-id\)$`,
+		},
+		{
+			name:     "failed to list security groups in shared VPC",
+			platform: sharedVPCPlatform(),
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{sharedVPC},
+			},
+			errorMsg: `failed to list security groups`,
+		},
+	}
+
+	generated := &resourceNames{
+		vpcs:           sets.NewString(infraID + "-vpc"),
+		subnets:        sets.NewString(infraID + "-subnet-control-plane-us-south-1"),
+		loadBalancers:  sets.NewString(infraID+"-kubernetes-api-public", infraID+"-kubernetes-api-private"),
+		securityGroups: sets.NewString(infraID + "-sg-control-plane"),
+	}
+	generatedSG := vpcv1.SecurityGroup{
+		Name:          strPtr(infraID + "-sg-control-plane"),
+		ID:            strPtr("conflict-sg-id"),
+		CRN:           strPtr("conflict-sg-crn"),
+		ResourceGroup: clusterRGRef,
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: no conflicts
+	// No mocks required
+
+	// Mocks: conflicting VPC
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedVPC.CRN).Return([]string{"team:network"}, nil)
+
+	// Mocks: conflicting subnet and load balancer
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedSubnet.CRN).Return([]string{}, nil)
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedLB.CRN).Return([]string{"kubernetes-io-cluster-other-cluster-xyz89:owned"}, nil)
+
+	// Mocks: resources tagged for the cluster
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedVPC.CRN).Return([]string{"kubernetes-io-cluster-valid-cluster-abc12:owned"}, nil)
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedSubnet.CRN).Return([]string{"kubernetes-io-cluster-valid-cluster-abc12:owned"}, nil)
+
+	// Mocks: resources in another resource group
+	// No mocks required
+
+	// Mocks: names not generated for the cluster
+	// No mocks required
+
+	// Mocks: failed to get tags
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedVPC.CRN).Return(nil, fmt.Errorf("failed to get tags"))
+
+	// Mocks: no security group conflicts in shared VPC
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, vpcID).Return([]vpcv1.SecurityGroup{{Name: strPtr("shared-sg"), ID: strPtr("shared-sg-id"), ResourceGroup: clusterRGRef}}, nil)
+
+	// Mocks: conflicting security group in shared VPC
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, vpcID).Return([]vpcv1.SecurityGroup{generatedSG}, nil)
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), *generatedSG.CRN).Return([]string{}, nil)
+
+	// Mocks: failed to list security groups in shared VPC
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), region, vpcID).Return(nil, fmt.Errorf("failed to list security groups"))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkNameCollisions(context.TODO(), ibmcloudClient, infraID, tc.platform, &clusterRG, generated, tc.resources)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWarnSharedVPC(t *testing.T) {
+	cases := []struct {
+		name      string
+		resources *vpcResources
+		warnMsgs  []string
+	}{
+		{
+			name: "no shared resources",
+			resources: &vpcResources{
+				vpcs:    []vpcv1.VPC{sharedVPC},
+				subnets: []vpcv1.Subnet{cpSubnet, cmpSubnet},
+			},
+		},
+		{
+			name: "VPC not found",
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{otherVPC},
+			},
+			warnMsgs: []string{
+				`^Skipping the shared VPC checks, VPC shared-vpc was not found in region us-south$`,
+			},
+		},
+		{
+			name: "subnet with default network ACL",
+			resources: &vpcResources{
+				vpcs: []vpcv1.VPC{sharedVPC},
+				subnets: []vpcv1.Subnet{
+					cpSubnet,
+					{
+						Name:       &cmpSubnetName,
+						ID:         &cmpSubnetID,
+						VPC:        &vpcv1.VPCReference{ID: &vpcID},
+						NetworkACL: &vpcv1.NetworkACLReference{ID: &defaultACLID},
+					},
+				},
+			},
+			warnMsgs: []string{
+				`^Subnet compute-subnet uses the default network ACL of VPC shared-vpc`,
+			},
+		},
+		{
+			name: "load balancer of another cluster",
+			resources: &vpcResources{
+				vpcs:          []vpcv1.VPC{sharedVPC},
+				subnets:       []vpcv1.Subnet{cpSubnet, cmpSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{otherLB},
+			},
+			warnMsgs: []string{
+				`^Load balancer other-lb of cluster other-cluster-xyz89 is already attached to subnets cp-subnet$`,
+			},
+		},
+		{
+			name: "load balancer of another workload",
+			resources: &vpcResources{
+				vpcs:          []vpcv1.VPC{sharedVPC},
+				subnets:       []vpcv1.Subnet{cpSubnet, cmpSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{otherLB},
+			},
+			warnMsgs: []string{
+				`^Load balancer other-lb of another workload is already attached to subnets cp-subnet$`,
+			},
+		},
+		{
+			name: "failed to get load balancer tags",
+			resources: &vpcResources{
+				vpcs:          []vpcv1.VPC{sharedVPC},
+				subnets:       []vpcv1.Subnet{cpSubnet, cmpSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{otherLB},
+			},
+			warnMsgs: []string{
+				`^Load balancer other-lb of another workload is already attached to subnets cp-subnet$`,
+			},
+		},
+		{
+			name: "load balancers of the cluster and in other subnets",
+			resources: &vpcResources{
+				vpcs:    []vpcv1.VPC{sharedVPC},
+				subnets: []vpcv1.Subnet{cpSubnet, cmpSubnet},
+				loadBalancers: []vpcv1.LoadBalancer{
+					{
+						Name:    strPtr(infraID + "-kubernetes-api-private"),
+						ID:      strPtr("cluster-lb-id"),
+						Subnets: []vpcv1.SubnetReference{{Name: &cpSubnetName, ID: &cpSubnetID}},
+					},
+					{
+						Name:    strPtr("unrelated-lb"),
+						ID:      strPtr("unrelated-lb-id"),
+						Subnets: []vpcv1.SubnetReference{{Name: strPtr("unrelated-subnet"), ID: strPtr("unrelated-subnet-id")}},
+					},
+				},
+			},
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: no shared resources
+	// No mocks required
+
+	// Mocks: VPC not found
+	// No mocks required
+
+	// Mocks: subnet with default network ACL
+	// No mocks required
+
+	// Mocks: load balancer of another cluster
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), otherLBCRN).Return([]string{"team:network", "kubernetes-io-cluster-other-cluster-xyz89:owned"}, nil)
+
+	// Mocks: load balancer of another workload
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), otherLBCRN).Return([]string{"team:network"}, nil)
+
+	// Mocks: failed to get load balancer tags
+	ibmcloudClient.EXPECT().GetAttachedTags(gomock.Any(), otherLBCRN).Return(nil, fmt.Errorf("failed to get tags"))
+
+	// Mocks: load balancers of the cluster and in other subnets
+	// No mocks required
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := logrusTest.NewGlobal()
+			warnSharedVPC(context.TODO(), ibmcloudClient, infraID, sharedVPCPlatform(), tc.resources)
+
+			warnings := []string{}
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings = append(warnings, entry.Message)
+				}
+			}
+			if assert.Len(t, warnings, len(tc.warnMsgs)) {
+				for i, msg := range tc.warnMsgs {
+					assert.Regexp(t, msg, warnings[i])
+				}
+			}
+		})
+	}
+}
@@ -38,6 +38,7 @@ import (
 	powervsconfig "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	vsphereconfig "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
 	"github.com/openshift/installer/pkg/asset/machines"
+	ibmcloudmachines "github.com/openshift/installer/pkg/asset/machines/ibmcloud"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/openshiftinstall"
 	"github.com/openshift/installer/pkg/asset/rhcos"
@@ -144,6 +145,13 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 	}
 
 	masterIgn := string(masterIgnAsset.Files()[0].Data)
+	if platform == ibmcloud.Name {
+		userData, err := ibmcloudmachines.UserData("master", masterIgnAsset.Files()[0].Data)
+		if err != nil {
+			return err
+		}
+		masterIgn = string(userData)
+	}
 	bootstrapIgn, err := injectInstallInfo(bootstrapIgnAsset.Files()[0].Data)
 	if err != nil {
 		return errors.Wrap(err, "unable to inject installation info")
@@ -600,7 +608,11 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		var cisCRN, dnsID string
 		vpcPermitted := false
 
-		if installConfig.Config.Publish == types.InternalPublishingStrategy {
+		switch {
+		case installConfig.Config.Platform.IBMCloud.IsUserProvisionedDNS():
+			// The DNS records are provided by the user, so no CIS or DNS
+			// Services instance is used.
+		case installConfig.Config.Publish == types.InternalPublishingStrategy:
 			// Get DNSInstanceCRN from InstallConfig metadata
 			dnsInstance, err := installConfig.IBMCloud.DNSInstance(ctx)
 			if err != nil {
@@ -616,7 +628,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 					return err
 				}
 			}
-		} else {
+		default:
 			// Get CISInstanceCRN from InstallConfig metadata
 			cisCRN, err = installConfig.IBMCloud.CISInstanceCRN(ctx)
 			if err != nil {
@@ -627,6 +639,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		data, err = ibmcloudtfvars.TFVars(
 			ibmcloudtfvars.TFVarsSources{
 				Auth:                     auth,
+				BootstrapInstanceType:    installConfig.Config.Platform.IBMCloud.BootstrapInstanceType,
 				CISInstanceCRN:           cisCRN,
 				DNSInstanceID:            dnsID,
 				ImageURL:                 string(*rhcosImage),
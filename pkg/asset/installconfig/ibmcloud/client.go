@@ -13,6 +13,7 @@ import (
 	"github.com/IBM/networking-go-sdk/dnssvcsv1"
 	"github.com/IBM/networking-go-sdk/dnszonesv1"
 	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/IBM/platform-services-go-sdk/globaltaggingv1"
 	"github.com/IBM/platform-services-go-sdk/iamidentityv1"
 	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/responses"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/ibmcloudclient_generated.go -package=mock
@@ -28,7 +30,9 @@ import (
 // API represents the calls made to the API.
 type API interface {
 	GetAPIKey() string
+	GetAttachedTags(ctx context.Context, crn string) ([]string, error)
 	GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error)
+	GetAvailableVPCZonesForRegion(ctx context.Context, region string) ([]string, error)
 	GetCISInstance(ctx context.Context, crnstr string) (*resourcecontrollerv2.ResourceInstance, error)
 	GetDNSInstance(ctx context.Context, crnstr string) (*resourcecontrollerv2.ResourceInstance, error)
 	GetDNSInstancePermittedNetworks(ctx context.Context, dnsID string, dnsZone string) ([]string, error)
@@ -38,25 +42,32 @@ type API interface {
 	GetDNSZoneIDByName(ctx context.Context, name string, publish types.PublishingStrategy) (string, error)
 	GetDNSZones(ctx context.Context, publish types.PublishingStrategy) ([]responses.DNSZoneResponse, error)
 	GetEncryptionKey(ctx context.Context, keyCRN string) (*responses.EncryptionKeyResponse, error)
+	GetImageByName(ctx context.Context, region string, name string) (*vpcv1.Image, error)
+	GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error)
 	GetResourceGroups(ctx context.Context) ([]resourcemanagerv2.ResourceGroup, error)
 	GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error)
+	GetSecurityGroups(ctx context.Context, region string, vpcID string) ([]vpcv1.SecurityGroup, error)
 	GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet, error)
-	GetSubnetByName(ctx context.Context, subnetName string, region string) (*vpcv1.Subnet, error)
-	GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error)
+	GetSubnetByName(ctx context.Context, subnetName string, region string, vpc string) (*vpcv1.Subnet, error)
+	GetSubnets(ctx context.Context, region string) ([]vpcv1.Subnet, error)
+	GetVSIProfiles(ctx context.Context, region string) ([]vpcv1.InstanceProfile, error)
 	GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error)
+	GetVPCAddressPrefixes(ctx context.Context, region string, vpcID string) ([]vpcv1.AddressPrefix, error)
 	GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error)
-	GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC, error)
+	GetVPCByName(ctx context.Context, vpcName string, resourceGroup string) (*vpcv1.VPC, error)
 	GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error)
 	SetVPCServiceURLForRegion(ctx context.Context, region string) error
 }
 
 // Client makes calls to the IBM Cloud API.
 type Client struct {
-	apiKey         string
-	managementAPI  *resourcemanagerv2.ResourceManagerV2
-	controllerAPI  *resourcecontrollerv2.ResourceControllerV2
-	vpcAPI         *vpcv1.VpcV1
-	dnsServicesAPI *dnssvcsv1.DnsSvcsV1
+	apiKey           string
+	serviceEndpoints []ibmcloud.ServiceEndpoint
+	managementAPI    *resourcemanagerv2.ResourceManagerV2
+	controllerAPI    *resourcecontrollerv2.ResourceControllerV2
+	vpcAPI           *vpcv1.VpcV1
+	dnsServicesAPI   *dnssvcsv1.DnsSvcsV1
+	globalTaggingAPI *globaltaggingv1.GlobalTaggingV1
 }
 
 // InstanceType is the IBM Cloud network services type being used
@@ -72,6 +83,18 @@ const (
 	cisServiceID = "75874a60-cb12-11e7-948e-37ac098eb1b9"
 	// dnsServiceID is the DNS Services' catalog service ID.
 	dnsServiceID = "b4ed8a30-936f-11e9-b289-1d079699cbe5"
+
+	// vpcListLimit is the maximum page size of VPC list calls.
+	vpcListLimit = 100
+	// resourceControllerListLimit is the maximum page size of resource
+	// instance list calls.
+	resourceControllerListLimit = 100
+	// dnsZonesListLimit is the page size of DNS Services zone list calls.
+	dnsZonesListLimit = 100
+	// cisZonesListLimit is the maximum page size of CIS zone list calls.
+	cisZonesListLimit = 50
+	// tagsListLimit is the maximum page size of Global Tagging list calls.
+	tagsListLimit = 1000
 )
 
 // VPCResourceNotFoundError represents an error for a VPC resoruce that is not found.
@@ -82,12 +105,21 @@ func (e *VPCResourceNotFoundError) Error() string {
 	return "Not Found"
 }
 
-// NewClient initializes a client with a session.
-func NewClient() (*Client, error) {
+// HasCredentials returns whether an IBM Cloud API key is available in
+// IC_API_KEY to call the IBM Cloud APIs.
+func HasCredentials() bool {
+	return os.Getenv("IC_API_KEY") != ""
+}
+
+// NewClient initializes a client with a session. The services with a custom
+// endpoint in serviceEndpoints are called on that endpoint instead of their
+// default one.
+func NewClient(serviceEndpoints []ibmcloud.ServiceEndpoint) (*Client, error) {
 	apiKey := os.Getenv("IC_API_KEY")
 
 	client := &Client{
-		apiKey: apiKey,
+		apiKey:           apiKey,
+		serviceEndpoints: serviceEndpoints,
 	}
 
 	if err := client.loadSDKServices(); err != nil {
@@ -103,6 +135,7 @@ func (c *Client) loadSDKServices() error {
 		c.loadResourceControllerAPI,
 		c.loadVPCV1API,
 		c.loadDNSServicesAPI,
+		c.loadGlobalTaggingAPI,
 	}
 
 	// Call all the load functions.
@@ -120,15 +153,52 @@ func (c *Client) GetAPIKey() string {
 	return c.apiKey
 }
 
+// serviceURL returns the custom endpoint of the service, or an empty string
+// to use the default endpoint of the service.
+func (c *Client) serviceURL(name ibmcloud.ServiceName) string {
+	platform := ibmcloud.Platform{ServiceEndpoints: c.serviceEndpoints}
+	return platform.ServiceEndpointURL(name)
+}
+
+// newAuthenticator returns an IamAuthenticator for the API key of the client,
+// using the custom IAM endpoint if there is one.
+func (c *Client) newAuthenticator() (*core.IamAuthenticator, error) {
+	return core.NewIamAuthenticatorBuilder().SetApiKey(c.GetAPIKey()).SetURL(c.serviceURL(ibmcloud.ServiceNameIAM)).Build()
+}
+
+// GetAttachedTags gets the user tags attached to the resource with the CRN.
+func (c *Client) GetAttachedTags(ctx context.Context, crn string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	tags := []string{}
+	listTagsOptions := c.globalTaggingAPI.NewListTagsOptions().SetAttachedTo(crn).SetTagType(globaltaggingv1.ListTagsOptionsTagTypeUserConst).SetLimit(tagsListLimit)
+	for offset := int64(0); ; offset += tagsListLimit {
+		listTagsOptions.SetOffset(offset)
+		tagList, detailedResponse, err := c.globalTaggingAPI.ListTagsWithContext(ctx, listTagsOptions)
+		if err != nil {
+			return nil, errors.Wrapf(NewAPIError(detailedResponse, err), "failed to list the tags of %s", crn)
+		}
+		for _, tag := range tagList.Items {
+			tags = append(tags, *tag.Name)
+		}
+		if len(tagList.Items) < tagsListLimit || tagList.TotalCount == nil || int64(len(tags)) >= *tagList.TotalCount {
+			break
+		}
+	}
+	return tags, nil
+}
+
 // GetAuthenticatorAPIKeyDetails gets detailed information on the API key used
 // for authentication to the IBM Cloud APIs
 func (c *Client) GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error) {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return nil, err
 	}
 	iamIdentityService, err := iamidentityv1.NewIamIdentityV1(&iamidentityv1.IamIdentityV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameIAM),
 	})
 	if err != nil {
 		return nil, err
@@ -136,9 +206,9 @@ func (c *Client) GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentit
 
 	options := iamIdentityService.NewGetAPIKeysDetailsOptions()
 	options.SetIamAPIKey(c.GetAPIKey())
-	details, _, err := iamIdentityService.GetAPIKeysDetailsWithContext(ctx, options)
+	details, detailedResponse, err := iamIdentityService.GetAPIKeysDetailsWithContext(ctx, options)
 	if err != nil {
-		return nil, err
+		return nil, NewAPIError(detailedResponse, err)
 	}
 	return details, nil
 }
@@ -149,9 +219,9 @@ func (c *Client) getInstance(ctx context.Context, crnstr string, iType InstanceT
 	defer cancel()
 
 	options := c.controllerAPI.NewGetResourceInstanceOptions(crnstr)
-	resourceInstance, _, err := c.controllerAPI.GetResourceInstance(options)
+	resourceInstance, detailedResponse, err := c.controllerAPI.GetResourceInstance(options)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get %s instances", iType)
+		return nil, errors.Wrapf(NewAPIError(detailedResponse, err), "failed to get %s instances", iType)
 	}
 
 	return resourceInstance, nil
@@ -173,9 +243,9 @@ func (c *Client) GetDNSInstancePermittedNetworks(ctx context.Context, dnsID stri
 	defer cancel()
 
 	listPermittedNetworksOptions := c.dnsServicesAPI.NewListPermittedNetworksOptions(dnsID, dnsZone)
-	permittedNetworks, _, err := c.dnsServicesAPI.ListPermittedNetworksWithContext(ctx, listPermittedNetworksOptions)
+	permittedNetworks, detailedResponse, err := c.dnsServicesAPI.ListPermittedNetworksWithContext(ctx, listPermittedNetworksOptions)
 	if err != nil {
-		return nil, err
+		return nil, NewAPIError(detailedResponse, err)
 	}
 
 	networks := []string{}
@@ -192,16 +262,27 @@ func (c *Client) GetDedicatedHostByName(ctx context.Context, name string, region
 		return nil, err
 	}
 
-	options := c.vpcAPI.NewListDedicatedHostsOptions()
-	dhosts, _, err := c.vpcAPI.ListDedicatedHostsWithContext(ctx, options)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to list dedicated hosts")
-	}
+	options := c.vpcAPI.NewListDedicatedHostsOptions().SetLimit(vpcListLimit)
+	for {
+		dhosts, detailedResponse, err := c.vpcAPI.ListDedicatedHostsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list dedicated hosts")
+		}
+
+		for _, dhost := range dhosts.DedicatedHosts {
+			if *dhost.Name == name {
+				return &dhost, nil
+			}
+		}
 
-	for _, dhost := range dhosts.DedicatedHosts {
-		if *dhost.Name == name {
-			return &dhost, nil
+		start, err := dhosts.GetNextStart()
+		if err != nil {
+			return nil, err
 		}
+		if start == nil {
+			break
+		}
+		options.SetStart(*start)
 	}
 
 	return nil, fmt.Errorf("dedicated host %q not found", name)
@@ -214,25 +295,38 @@ func (c *Client) GetDedicatedHostProfiles(ctx context.Context, region string) ([
 		return nil, err
 	}
 
-	profilesOptions := c.vpcAPI.NewListDedicatedHostProfilesOptions()
-	profiles, _, err := c.vpcAPI.ListDedicatedHostProfilesWithContext(ctx, profilesOptions)
-	if err != nil {
-		return nil, err
-	}
+	allProfiles := []vpcv1.DedicatedHostProfile{}
+	profilesOptions := c.vpcAPI.NewListDedicatedHostProfilesOptions().SetLimit(vpcListLimit)
+	for {
+		profiles, detailedResponse, err := c.vpcAPI.ListDedicatedHostProfilesWithContext(ctx, profilesOptions)
+		if err != nil {
+			return nil, NewAPIError(detailedResponse, err)
+		}
+		allProfiles = append(allProfiles, profiles.Profiles...)
 
-	return profiles.Profiles, nil
+		start, err := profiles.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		profilesOptions.SetStart(*start)
+	}
+	return allProfiles, nil
 }
 
 // GetDNSRecordsByName gets DNS records in specific Cloud Internet Services instance
 // by its CRN, zone ID, and DNS record name.
 func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID string, recordName string) ([]dnsrecordsv1.DnsrecordDetails, error) {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return nil, err
 	}
 	// Set CIS DNS record service
 	dnsService, err := dnsrecordsv1.NewDnsRecordsV1(&dnsrecordsv1.DnsRecordsV1Options{
 		Authenticator:  authenticator,
+		URL:            c.serviceURL(ibmcloud.ServiceNameCIS),
 		Crn:            core.StringPtr(crnstr),
 		ZoneIdentifier: core.StringPtr(zoneID),
 	})
@@ -241,11 +335,11 @@ func (c *Client) GetDNSRecordsByName(ctx context.Context, crnstr string, zoneID
 	}
 
 	// Get CIS DNS records by name
-	records, _, err := dnsService.ListAllDnsRecordsWithContext(ctx, &dnsrecordsv1.ListAllDnsRecordsOptions{
+	records, detailedResponse, err := dnsService.ListAllDnsRecordsWithContext(ctx, &dnsrecordsv1.ListAllDnsRecordsOptions{
 		Name: core.StringPtr(recordName),
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "could not retrieve DNS records")
+		return nil, errors.Wrap(NewAPIError(detailedResponse, err), "could not retrieve DNS records")
 	}
 
 	return records.Result, nil
@@ -279,34 +373,43 @@ func (c *Client) GetDNSZones(ctx context.Context, publish types.PublishingStrate
 }
 
 func (c *Client) getDNSDNSZones(ctx context.Context) ([]responses.DNSZoneResponse, error) {
-	options := c.controllerAPI.NewListResourceInstancesOptions()
-	options.SetResourceID(dnsServiceID)
-
-	listResourceInstancesResponse, _, err := c.controllerAPI.ListResourceInstances(options)
+	instances, err := c.listResourceInstances(ctx, dnsServiceID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get dns instance")
 	}
 
 	var allZones []responses.DNSZoneResponse
-	for _, instance := range listResourceInstancesResponse.Resources {
-		authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	for _, instance := range instances {
+		authenticator, err := c.newAuthenticator()
 		if err != nil {
 			return nil, err
 		}
 		dnsZoneService, err := dnszonesv1.NewDnsZonesV1(&dnszonesv1.DnsZonesV1Options{
 			Authenticator: authenticator,
+			URL:           c.serviceURL(ibmcloud.ServiceNameDNSServices),
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to list DNS zones")
 		}
 
+		var dnsZones []dnszonesv1.Dnszone
 		options := dnsZoneService.NewListDnszonesOptions(*instance.GUID)
-		result, _, err := dnsZoneService.ListDnszones(options)
-		if result == nil {
-			return nil, err
+		options.Limit = core.Int64Ptr(dnsZonesListLimit)
+		for offset := int64(0); ; {
+			options.Offset = core.Int64Ptr(offset)
+			result, detailedResponse, err := dnsZoneService.ListDnszones(options)
+			if result == nil {
+				return nil, NewAPIError(detailedResponse, err)
+			}
+			dnsZones = append(dnsZones, result.Dnszones...)
+
+			offset += int64(len(result.Dnszones))
+			if len(result.Dnszones) == 0 || result.TotalCount == nil || offset >= *result.TotalCount {
+				break
+			}
 		}
 
-		for _, zone := range result.Dnszones {
+		for _, zone := range dnsZones {
 			stateLower := strings.ToLower(*zone.State)
 			// DNS Zones can be 'pending_network_add' (without a permitted network, added during TF)
 			if stateLower == dnszonesv1.Dnszone_State_Active || stateLower == dnszonesv1.Dnszone_State_PendingNetworkAdd {
@@ -327,37 +430,44 @@ func (c *Client) getDNSDNSZones(ctx context.Context) ([]responses.DNSZoneRespons
 }
 
 func (c *Client) getCISDNSZones(ctx context.Context) ([]responses.DNSZoneResponse, error) {
-	options := c.controllerAPI.NewListResourceInstancesOptions()
-	options.SetResourceID(cisServiceID)
-
-	listResourceInstancesResponse, _, err := c.controllerAPI.ListResourceInstances(options)
+	instances, err := c.listResourceInstances(ctx, cisServiceID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get cis instance")
 	}
 
 	var allZones []responses.DNSZoneResponse
-	for _, instance := range listResourceInstancesResponse.Resources {
-		authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	for _, instance := range instances {
+		authenticator, err := c.newAuthenticator()
 		if err != nil {
 			return nil, err
 		}
 		crnstr := instance.CRN
 		zonesService, err := zonesv1.NewZonesV1(&zonesv1.ZonesV1Options{
 			Authenticator: authenticator,
+			URL:           c.serviceURL(ibmcloud.ServiceNameCIS),
 			Crn:           crnstr,
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to list DNS zones")
 		}
 
-		options := zonesService.NewListZonesOptions()
-		listZonesResponse, _, err := zonesService.ListZones(options)
+		var cisZones []zonesv1.ZoneDetails
+		options := zonesService.NewListZonesOptions().SetPerPage(cisZonesListLimit)
+		for page := int64(1); ; page++ {
+			options.SetPage(page)
+			listZonesResponse, detailedResponse, err := zonesService.ListZonesWithContext(ctx, options)
+			if listZonesResponse == nil {
+				return nil, NewAPIError(detailedResponse, err)
+			}
+			cisZones = append(cisZones, listZonesResponse.Result...)
 
-		if listZonesResponse == nil {
-			return nil, err
+			info := listZonesResponse.ResultInfo
+			if len(listZonesResponse.Result) == 0 || info == nil || info.TotalCount == nil || int64(len(cisZones)) >= *info.TotalCount {
+				break
+			}
 		}
 
-		for _, zone := range listZonesResponse.Result {
+		for _, zone := range cisZones {
 			if *zone.Status == "active" {
 				zoneStruct := responses.DNSZoneResponse{
 					Name:            *zone.Name,
@@ -375,12 +485,87 @@ func (c *Client) getCISDNSZones(ctx context.Context) ([]responses.DNSZoneRespons
 	return allZones, nil
 }
 
+// listResourceInstances returns all resource instances of a catalog service.
+func (c *Client) listResourceInstances(ctx context.Context, resourceID string) ([]resourcecontrollerv2.ResourceInstance, error) {
+	instances := []resourcecontrollerv2.ResourceInstance{}
+	options := c.controllerAPI.NewListResourceInstancesOptions().SetResourceID(resourceID).SetLimit(resourceControllerListLimit)
+	for {
+		list, detailedResponse, err := c.controllerAPI.ListResourceInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, NewAPIError(detailedResponse, err)
+		}
+		instances = append(instances, list.Resources...)
+
+		start, err := list.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		options.SetStart(*start)
+	}
+	return instances, nil
+}
+
 // GetEncryptionKey gets data for an encryption key
 func (c *Client) GetEncryptionKey(ctx context.Context, keyCRN string) (*responses.EncryptionKeyResponse, error) {
 	// TODO: IBM: Call KMS / Hyperprotect Crpyto APIs.
 	return &responses.EncryptionKeyResponse{}, nil
 }
 
+// GetImageByName gets a private image in a region by its name.
+func (c *Client) GetImageByName(ctx context.Context, region string, name string) (*vpcv1.Image, error) {
+	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	listImagesOptions := c.vpcAPI.NewListImagesOptions().SetName(name).SetVisibility(vpcv1.ListImagesOptionsVisibilityPrivateConst)
+	images, detailedResponse, err := c.vpcAPI.ListImagesWithContext(ctx, listImagesOptions)
+	if err != nil {
+		return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list images")
+	}
+	if len(images.Images) == 0 {
+		return nil, &VPCResourceNotFoundError{}
+	}
+	return &images.Images[0], nil
+}
+
+// GetLoadBalancers gets all load balancers in a region.
+func (c *Client) GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error) {
+	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	loadBalancers := []vpcv1.LoadBalancer{}
+	listLoadBalancersOptions := c.vpcAPI.NewListLoadBalancersOptions().SetLimit(vpcListLimit)
+	for {
+		lbCollection, detailedResponse, err := c.vpcAPI.ListLoadBalancersWithContext(ctx, listLoadBalancersOptions)
+		if err != nil {
+			return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list load balancers")
+		}
+		loadBalancers = append(loadBalancers, lbCollection.LoadBalancers...)
+
+		start, err := lbCollection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listLoadBalancersOptions.SetStart(*start)
+	}
+	return loadBalancers, nil
+}
+
 // GetResourceGroup gets a resource group by its name or ID.
 func (c *Client) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -411,13 +596,44 @@ func (c *Client) GetResourceGroups(ctx context.Context) ([]resourcemanagerv2.Res
 
 	options := c.managementAPI.NewListResourceGroupsOptions()
 	options.SetAccountID(*apikey.AccountID)
-	listResourceGroupsResponse, _, err := c.managementAPI.ListResourceGroupsWithContext(ctx, options)
+	listResourceGroupsResponse, detailedResponse, err := c.managementAPI.ListResourceGroupsWithContext(ctx, options)
 	if err != nil {
-		return nil, err
+		return nil, NewAPIError(detailedResponse, err)
 	}
 	return listResourceGroupsResponse.Resources, nil
 }
 
+// GetSecurityGroups gets the security groups of a VPC.
+func (c *Client) GetSecurityGroups(ctx context.Context, region string, vpcID string) ([]vpcv1.SecurityGroup, error) {
+	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	securityGroups := []vpcv1.SecurityGroup{}
+	listSecurityGroupsOptions := c.vpcAPI.NewListSecurityGroupsOptions().SetVPCID(vpcID).SetLimit(vpcListLimit)
+	for {
+		groups, detailedResponse, err := c.vpcAPI.ListSecurityGroupsWithContext(ctx, listSecurityGroupsOptions)
+		if err != nil {
+			return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list security groups")
+		}
+		securityGroups = append(securityGroups, groups.SecurityGroups...)
+
+		start, err := groups.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listSecurityGroupsOptions.SetStart(*start)
+	}
+	return securityGroups, nil
+}
+
 // GetSubnet gets a subnet by its ID.
 func (c *Client) GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -427,11 +643,12 @@ func (c *Client) GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet,
 	if detailedResponse.GetStatusCode() == http.StatusNotFound {
 		return nil, &VPCResourceNotFoundError{}
 	}
-	return subnet, err
+	return subnet, NewAPIError(detailedResponse, err)
 }
 
-// GetSubnetByName gets a subnet by its Name.
-func (c *Client) GetSubnetByName(ctx context.Context, subnetName string, region string) (*vpcv1.Subnet, error) {
+// GetSubnetByName gets a subnet by its Name. If vpc is not empty, only subnets
+// in the VPC with that name or ID are matched.
+func (c *Client) GetSubnetByName(ctx context.Context, subnetName string, region string, vpc string) (*vpcv1.Subnet, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
@@ -440,27 +657,74 @@ func (c *Client) GetSubnetByName(ctx context.Context, subnetName string, region
 		return nil, err
 	}
 
-	listSubnetsOptions := c.vpcAPI.NewListSubnetsOptions()
-	subnetCollection, detailedResponse, err := c.vpcAPI.ListSubnetsWithContext(ctx, listSubnetsOptions)
+	listSubnetsOptions := c.vpcAPI.NewListSubnetsOptions().SetLimit(vpcListLimit)
+	for {
+		subnetCollection, detailedResponse, err := c.vpcAPI.ListSubnetsWithContext(ctx, listSubnetsOptions)
+		if err != nil {
+			return nil, NewAPIError(detailedResponse, err)
+		} else if detailedResponse.GetStatusCode() == http.StatusNotFound {
+			return nil, &VPCResourceNotFoundError{}
+		}
+		for _, subnet := range subnetCollection.Subnets {
+			if subnetName == *subnet.Name && (vpc == "" || vpc == *subnet.VPC.ID || vpc == *subnet.VPC.Name) {
+				return &subnet, nil
+			}
+		}
+
+		start, err := subnetCollection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listSubnetsOptions.SetStart(*start)
+	}
+	return nil, &VPCResourceNotFoundError{}
+}
+
+// GetSubnets gets all subnets in a region.
+func (c *Client) GetSubnets(ctx context.Context, region string) ([]vpcv1.Subnet, error) {
+	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
 	if err != nil {
-		return nil, err
-	} else if detailedResponse.GetStatusCode() == http.StatusNotFound {
-		return nil, &VPCResourceNotFoundError{}
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
 	}
-	for _, subnet := range subnetCollection.Subnets {
-		if subnetName == *subnet.Name {
-			return &subnet, nil
+
+	subnets := []vpcv1.Subnet{}
+	listSubnetsOptions := c.vpcAPI.NewListSubnetsOptions().SetLimit(vpcListLimit)
+	for {
+		subnetCollection, detailedResponse, err := c.vpcAPI.ListSubnetsWithContext(ctx, listSubnetsOptions)
+		if err != nil {
+			return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list subnets")
 		}
+		subnets = append(subnets, subnetCollection.Subnets...)
+
+		start, err := subnetCollection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listSubnetsOptions.SetStart(*start)
 	}
-	return nil, &VPCResourceNotFoundError{}
+	return subnets, nil
 }
 
-// GetVSIProfiles gets a list of all VSI profiles.
-func (c *Client) GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error) {
+// GetVSIProfiles gets a list of the VSI profiles supported in a region.
+func (c *Client) GetVSIProfiles(ctx context.Context, region string) ([]vpcv1.InstanceProfile, error) {
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
 	listInstanceProfilesOptions := c.vpcAPI.NewListInstanceProfilesOptions()
-	profiles, _, err := c.vpcAPI.ListInstanceProfilesWithContext(ctx, listInstanceProfilesOptions)
+	profiles, detailedResponse, err := c.vpcAPI.ListInstanceProfilesWithContext(ctx, listInstanceProfilesOptions)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to list vpc vsi profiles")
+		return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list vpc vsi profiles")
 	}
 	return profiles.Profiles, nil
 }
@@ -470,20 +734,20 @@ func (c *Client) GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
-	regions, err := c.getVPCRegions(ctx)
+	serviceURLs, err := c.getVPCServiceURLs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, region := range regions {
-		err := c.vpcAPI.SetServiceURL(fmt.Sprintf("%s/v1", *region.Endpoint))
+	for _, serviceURL := range serviceURLs {
+		err := c.vpcAPI.SetServiceURL(serviceURL)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to set vpc api service url")
 		}
 
 		if vpc, detailedResponse, err := c.vpcAPI.GetVPC(c.vpcAPI.NewGetVPCOptions(vpcID)); err != nil {
 			if detailedResponse.GetStatusCode() != http.StatusNotFound {
-				return nil, err
+				return nil, NewAPIError(detailedResponse, err)
 			}
 		} else if vpc != nil {
 			return vpc, nil
@@ -493,6 +757,37 @@ func (c *Client) GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error) {
 	return nil, &VPCResourceNotFoundError{}
 }
 
+// GetVPCAddressPrefixes gets the address prefixes of a VPC.
+func (c *Client) GetVPCAddressPrefixes(ctx context.Context, region string, vpcID string) ([]vpcv1.AddressPrefix, error) {
+	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	err := c.SetVPCServiceURLForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	addressPrefixes := []vpcv1.AddressPrefix{}
+	listAddressPrefixesOptions := c.vpcAPI.NewListVPCAddressPrefixesOptions(vpcID).SetLimit(vpcListLimit)
+	for {
+		prefixes, detailedResponse, err := c.vpcAPI.ListVPCAddressPrefixesWithContext(ctx, listAddressPrefixesOptions)
+		if err != nil {
+			return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list vpc address prefixes")
+		}
+		addressPrefixes = append(addressPrefixes, prefixes.AddressPrefixes...)
+
+		start, err := prefixes.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listAddressPrefixesOptions.SetStart(*start)
+	}
+	return addressPrefixes, nil
+}
+
 // GetVPCs gets all VPCs in a region
 func (c *Client) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -504,43 +799,69 @@ func (c *Client) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error
 	}
 
 	allVPCs := []vpcv1.VPC{}
-	if vpcs, detailedResponse, err := c.vpcAPI.ListVpcs(c.vpcAPI.NewListVpcsOptions()); err != nil {
-		if detailedResponse.GetStatusCode() != http.StatusNotFound {
-			return nil, err
+	listVpcsOptions := c.vpcAPI.NewListVpcsOptions().SetLimit(vpcListLimit)
+	for {
+		vpcs, detailedResponse, err := c.vpcAPI.ListVpcsWithContext(ctx, listVpcsOptions)
+		if err != nil {
+			if detailedResponse.GetStatusCode() != http.StatusNotFound {
+				return nil, NewAPIError(detailedResponse, err)
+			}
+			break
 		}
-	} else if vpcs != nil {
 		allVPCs = append(allVPCs, vpcs.Vpcs...)
+
+		start, err := vpcs.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		listVpcsOptions.SetStart(*start)
 	}
 	return allVPCs, nil
 }
 
-// GetVPCByName gets a VPC by its name.
-func (c *Client) GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC, error) {
+// GetVPCByName gets a VPC by its name. If resourceGroup is not empty, only VPCs
+// in the resource group with that name or ID are matched.
+func (c *Client) GetVPCByName(ctx context.Context, vpcName string, resourceGroup string) (*vpcv1.VPC, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
-	regions, err := c.getVPCRegions(ctx)
+	serviceURLs, err := c.getVPCServiceURLs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, region := range regions {
-		err := c.vpcAPI.SetServiceURL(fmt.Sprintf("%s/v1", *region.Endpoint))
+	for _, serviceURL := range serviceURLs {
+		err := c.vpcAPI.SetServiceURL(serviceURL)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to set vpc api service url")
 		}
 
-		vpcs, detailedResponse, err := c.vpcAPI.ListVpcsWithContext(ctx, c.vpcAPI.NewListVpcsOptions())
-		if err != nil {
-			if detailedResponse.GetStatusCode() != http.StatusNotFound {
-				return nil, err
+		listVpcsOptions := c.vpcAPI.NewListVpcsOptions().SetLimit(vpcListLimit)
+		for {
+			vpcs, detailedResponse, err := c.vpcAPI.ListVpcsWithContext(ctx, listVpcsOptions)
+			if err != nil {
+				if detailedResponse.GetStatusCode() != http.StatusNotFound {
+					return nil, NewAPIError(detailedResponse, err)
+				}
+				break
 			}
-		} else {
 			for _, vpc := range vpcs.Vpcs {
-				if *vpc.Name == vpcName {
+				if *vpc.Name == vpcName && (resourceGroup == "" || resourceGroup == *vpc.ResourceGroup.ID || resourceGroup == *vpc.ResourceGroup.Name) {
 					return &vpc, nil
 				}
 			}
+
+			start, err := vpcs.GetNextStart()
+			if err != nil {
+				return nil, err
+			}
+			if start == nil {
+				break
+			}
+			listVpcsOptions.SetStart(*start)
 		}
 	}
 
@@ -549,39 +870,71 @@ func (c *Client) GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC,
 
 // GetVPCZonesForRegion gets the supported zones for a VPC region.
 func (c *Client) GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error) {
+	return c.getVPCZones(ctx, region, false)
+}
+
+// GetAvailableVPCZonesForRegion gets the supported zones for a VPC region
+// which are available, excluding impaired and unavailable zones.
+func (c *Client) GetAvailableVPCZonesForRegion(ctx context.Context, region string) ([]string, error) {
+	return c.getVPCZones(ctx, region, true)
+}
+
+func (c *Client) getVPCZones(ctx context.Context, region string, availableOnly bool) ([]string, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	regionZonesOptions := c.vpcAPI.NewListRegionZonesOptions(region)
-	zones, _, err := c.vpcAPI.ListRegionZonesWithContext(ctx, regionZonesOptions)
+	zones, detailedResponse, err := c.vpcAPI.ListRegionZonesWithContext(ctx, regionZonesOptions)
 	if err != nil {
-		return nil, err
+		return nil, NewAPIError(detailedResponse, err)
 	}
 
-	response := make([]string, len(zones.Zones))
-	for idx, zone := range zones.Zones {
-		response[idx] = *zone.Name
+	response := make([]string, 0, len(zones.Zones))
+	for _, zone := range zones.Zones {
+		if availableOnly && (zone.Status == nil || *zone.Status != vpcv1.ZoneStatusAvailableConst) {
+			continue
+		}
+		response = append(response, *zone.Name)
+	}
+	return response, nil
+}
+
+// getVPCServiceURLs returns the VPC endpoints of every region, or only the
+// custom VPC endpoint if there is one.
+func (c *Client) getVPCServiceURLs(ctx context.Context) ([]string, error) {
+	if serviceURL := c.serviceURL(ibmcloud.ServiceNameVPC); serviceURL != "" {
+		return []string{serviceURL}, nil
+	}
+
+	regions, err := c.getVPCRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	serviceURLs := make([]string, 0, len(regions))
+	for _, region := range regions {
+		serviceURLs = append(serviceURLs, fmt.Sprintf("%s/v1", *region.Endpoint))
 	}
-	return response, err
+	return serviceURLs, nil
 }
 
 func (c *Client) getVPCRegions(ctx context.Context) ([]vpcv1.Region, error) {
 	listRegionsOptions := c.vpcAPI.NewListRegionsOptions()
-	listRegionsResponse, _, err := c.vpcAPI.ListRegionsWithContext(ctx, listRegionsOptions)
+	listRegionsResponse, detailedResponse, err := c.vpcAPI.ListRegionsWithContext(ctx, listRegionsOptions)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to list vpc regions")
+		return nil, errors.Wrap(NewAPIError(detailedResponse, err), "failed to list vpc regions")
 	}
 
 	return listRegionsResponse.Regions, nil
 }
 
 func (c *Client) loadResourceManagementAPI() error {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return err
 	}
 	options := &resourcemanagerv2.ResourceManagerV2Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameResourceManager),
 	}
 	resourceManagerV2Service, err := resourcemanagerv2.NewResourceManagerV2(options)
 	if err != nil {
@@ -592,12 +945,13 @@ func (c *Client) loadResourceManagementAPI() error {
 }
 
 func (c *Client) loadResourceControllerAPI() error {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return err
 	}
 	options := &resourcecontrollerv2.ResourceControllerV2Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameResourceController),
 	}
 	resourceControllerV2Service, err := resourcecontrollerv2.NewResourceControllerV2(options)
 	if err != nil {
@@ -608,12 +962,13 @@ func (c *Client) loadResourceControllerAPI() error {
 }
 
 func (c *Client) loadVPCV1API() error {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return err
 	}
 	vpcService, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameVPC),
 	})
 	if err != nil {
 		return err
@@ -623,12 +978,13 @@ func (c *Client) loadVPCV1API() error {
 }
 
 func (c *Client) loadDNSServicesAPI() error {
-	authenticator, err := NewIamAuthenticator(c.GetAPIKey())
+	authenticator, err := c.newAuthenticator()
 	if err != nil {
 		return err
 	}
 	dnsService, err := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
 		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameDNSServices),
 	})
 	if err != nil {
 		return err
@@ -637,12 +993,32 @@ func (c *Client) loadDNSServicesAPI() error {
 	return nil
 }
 
-// SetVPCServiceURLForRegion will set the VPC Service URL to a specific IBM Cloud Region, in order to access Region scoped resources
+func (c *Client) loadGlobalTaggingAPI() error {
+	authenticator, err := c.newAuthenticator()
+	if err != nil {
+		return err
+	}
+	globalTaggingService, err := globaltaggingv1.NewGlobalTaggingV1(&globaltaggingv1.GlobalTaggingV1Options{
+		Authenticator: authenticator,
+		URL:           c.serviceURL(ibmcloud.ServiceNameGlobalTagging),
+	})
+	if err != nil {
+		return err
+	}
+	c.globalTaggingAPI = globalTaggingService
+	return nil
+}
+
+// SetVPCServiceURLForRegion will set the VPC Service URL to a specific IBM Cloud Region, in order to access Region scoped resources.
+// A custom VPC endpoint is always used as is.
 func (c *Client) SetVPCServiceURLForRegion(ctx context.Context, region string) error {
+	if c.serviceURL(ibmcloud.ServiceNameVPC) != "" {
+		return nil
+	}
 	regionOptions := c.vpcAPI.NewGetRegionOptions(region)
-	vpcRegion, _, err := c.vpcAPI.GetRegionWithContext(ctx, regionOptions)
+	vpcRegion, detailedResponse, err := c.vpcAPI.GetRegionWithContext(ctx, regionOptions)
 	if err != nil {
-		return err
+		return NewAPIError(detailedResponse, err)
 	}
 	err = c.vpcAPI.SetServiceURL(fmt.Sprintf("%s/v1", *vpcRegion.Endpoint))
 	if err != nil {
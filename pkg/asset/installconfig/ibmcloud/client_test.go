@@ -0,0 +1,140 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+// newPagedVPCServer returns a VPC API server for the us-south region which
+// serves each collection path from the pages in order, following the start
+// query parameter.
+func newPagedVPCServer(t *testing.T, collection string, pages []string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/regions/us-south":
+			fmt.Fprintf(w, `{"endpoint": %q, "name": "us-south", "status": "available"}`, server.URL)
+		case fmt.Sprintf("/v1/%s", collection):
+			page := 0
+			if start := r.URL.Query().Get("start"); start != "" {
+				fmt.Sscanf(start, "page-%d", &page)
+			}
+			assert.Equal(t, "100", r.URL.Query().Get("limit"))
+			next := ""
+			if page+1 < len(pages) {
+				next = fmt.Sprintf(`, "next": {"href": "%s/v1/%s?start=page-%d"}`, server.URL, collection, page+1)
+			}
+			fmt.Fprintf(w, `{"first": {"href": "%s/v1/%s"}, "limit": 100%s, %s}`, server.URL, collection, next, pages[page])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func newTestVPCClient(t *testing.T, server *httptest.Server) *Client {
+	vpcAPI, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: &core.NoAuthAuthenticator{},
+		URL:           fmt.Sprintf("%s/v1", server.URL),
+	})
+	if err != nil {
+		t.Fatalf("failed to create vpc api: %v", err)
+	}
+	return &Client{vpcAPI: vpcAPI}
+}
+
+func TestGetVPCsPaged(t *testing.T) {
+	server := newPagedVPCServer(t, "vpcs", []string{
+		`"vpcs": [{"id": "vpc-1", "name": "vpc-1"}, {"id": "vpc-2", "name": "vpc-2"}]`,
+		`"vpcs": [{"id": "vpc-3", "name": "vpc-3"}]`,
+	})
+	defer server.Close()
+
+	vpcs, err := newTestVPCClient(t, server).GetVPCs(context.Background(), "us-south")
+	assert.NoError(t, err)
+	var ids []string
+	for _, vpc := range vpcs {
+		ids = append(ids, *vpc.ID)
+	}
+	assert.Equal(t, []string{"vpc-1", "vpc-2", "vpc-3"}, ids)
+}
+
+func TestGetDedicatedHostByNamePaged(t *testing.T) {
+	server := newPagedVPCServer(t, "dedicated_hosts", []string{
+		`"dedicated_hosts": [{"id": "dh-1", "name": "dh-1"}]`,
+		`"dedicated_hosts": [{"id": "dh-2", "name": "dh-2"}]`,
+	})
+	defer server.Close()
+	client := newTestVPCClient(t, server)
+
+	dhost, err := client.GetDedicatedHostByName(context.Background(), "dh-2", "us-south")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "dh-2", *dhost.ID)
+	}
+
+	_, err = client.GetDedicatedHostByName(context.Background(), "dh-3", "us-south")
+	assert.EqualError(t, err, `dedicated host "dh-3" not found`)
+}
+
+func TestGetDedicatedHostProfilesPaged(t *testing.T) {
+	server := newPagedVPCServer(t, "dedicated_host/profiles", []string{
+		`"profiles": [{"name": "mx2-host-152x1216"}]`,
+		`"profiles": [{"name": "bx2-host-152x608"}]`,
+	})
+	defer server.Close()
+
+	profiles, err := newTestVPCClient(t, server).GetDedicatedHostProfiles(context.Background(), "us-south")
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 2)
+}
+
+func TestGetAvailableVPCZonesForRegion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"zones": [
+			{"name": "us-south-1", "status": "available"},
+			{"name": "us-south-2", "status": "impaired"},
+			{"name": "us-south-3", "status": "available"}
+		]}`)
+	}))
+	defer server.Close()
+	client := newTestVPCClient(t, server)
+
+	zones, err := client.GetAvailableVPCZonesForRegion(context.Background(), "us-south")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-south-1", "us-south-3"}, zones)
+
+	zones, err = client.GetVPCZonesForRegion(context.Background(), "us-south")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-south-1", "us-south-2", "us-south-3"}, zones)
+}
+
+func TestCustomVPCEndpoint(t *testing.T) {
+	server := newPagedVPCServer(t, "vpcs", []string{
+		`"vpcs": [{"id": "vpc-1", "name": "vpc-1"}]`,
+	})
+	defer server.Close()
+	client := newTestVPCClient(t, server)
+	client.serviceEndpoints = []ibmcloud.ServiceEndpoint{{Name: ibmcloud.ServiceNameVPC, URL: fmt.Sprintf("%s/v1", server.URL)}}
+
+	// The server has no eu-de region, so the region endpoint must not be
+	// looked up.
+	vpcs, err := client.GetVPCs(context.Background(), "eu-de")
+	if assert.NoError(t, err) {
+		assert.Len(t, vpcs, 1)
+	}
+
+	// Nor are the regions listed to search every region.
+	_, err = client.GetVPC(context.Background(), "vpc-2")
+	assert.IsType(t, &VPCResourceNotFoundError{}, err)
+}
@@ -0,0 +1,148 @@
+package ibmcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// APIErrorReason classifies a failed IBM Cloud API call.
+type APIErrorReason string
+
+const (
+	// ReasonNotFound indicates the requested resource does not exist.
+	ReasonNotFound APIErrorReason = "NotFound"
+	// ReasonForbidden indicates the credentials are missing, invalid or lack
+	// the required access.
+	ReasonForbidden APIErrorReason = "Forbidden"
+	// ReasonConflict indicates the request conflicts with the current state of
+	// the resource, such as a resource that already exists.
+	ReasonConflict APIErrorReason = "Conflict"
+	// ReasonResourceInUse indicates the resource cannot be changed or deleted
+	// while other resources still depend on it.
+	ReasonResourceInUse APIErrorReason = "ResourceInUse"
+	// ReasonRateLimited indicates the request was throttled.
+	ReasonRateLimited APIErrorReason = "RateLimited"
+	// ReasonQuotaExceeded indicates the request would exceed an account quota.
+	ReasonQuotaExceeded APIErrorReason = "QuotaExceeded"
+	// ReasonUnknown indicates a failure that does not match any other reason.
+	ReasonUnknown APIErrorReason = "Unknown"
+)
+
+// remediations are the user-facing hints appended to APIError messages.
+var remediations = map[APIErrorReason]string{
+	ReasonForbidden:     "verify the API key in IC_API_KEY is valid and has the access policies required for installation",
+	ReasonRateLimited:   "the request was throttled by IBM Cloud, retry the operation later",
+	ReasonQuotaExceeded: "request a quota increase for the account or remove unused resources",
+}
+
+// traceHeaders are the response headers carrying the ID IBM Cloud support
+// uses to trace a request, in order of preference.
+var traceHeaders = []string{"X-Request-Id", "Transaction-Id", "X-Correlation-Id"}
+
+// APIError is a failed IBM Cloud API call, classified by reason.
+type APIError struct {
+	Reason     APIErrorReason
+	StatusCode int
+	TraceID    string
+	Err        error
+}
+
+// Error returns the error message, including the HTTP status and trace ID of
+// the call when known, and a remediation hint when one is known for the reason.
+func (e *APIError) Error() string {
+	msg := e.Err.Error()
+	if e.StatusCode != 0 {
+		details := fmt.Sprintf("status %d", e.StatusCode)
+		if e.TraceID != "" {
+			details = fmt.Sprintf("%s, trace %s", details, e.TraceID)
+		}
+		msg = fmt.Sprintf("%s [%s]", msg, details)
+	}
+	if hint, ok := remediations[e.Reason]; ok {
+		return fmt.Sprintf("%s (%s)", msg, hint)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable returns whether the failed call may succeed if retried.
+func (e *APIError) Retryable() bool {
+	return e.Reason == ReasonRateLimited || e.StatusCode >= http.StatusInternalServerError
+}
+
+// NewAPIError classifies an error returned by an IBM Cloud API call, using
+// the response of the call when available, and records the response trace ID
+// for support cases. A nil error returns nil.
+func NewAPIError(response *core.DetailedResponse, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	apiErr := &APIError{
+		Reason: ReasonUnknown,
+		Err:    err,
+	}
+	if response == nil {
+		return apiErr
+	}
+	apiErr.StatusCode = response.GetStatusCode()
+	for _, header := range traceHeaders {
+		if traceID := response.GetHeaders().Get(header); traceID != "" {
+			apiErr.TraceID = traceID
+			break
+		}
+	}
+
+	switch {
+	case hasErrorCode(response, "quota"):
+		apiErr.Reason = ReasonQuotaExceeded
+	case apiErr.StatusCode == http.StatusNotFound:
+		apiErr.Reason = ReasonNotFound
+	case apiErr.StatusCode == http.StatusUnauthorized, apiErr.StatusCode == http.StatusForbidden:
+		apiErr.Reason = ReasonForbidden
+	case apiErr.StatusCode == http.StatusConflict && hasErrorCode(response, "in_use"):
+		apiErr.Reason = ReasonResourceInUse
+	case apiErr.StatusCode == http.StatusConflict:
+		apiErr.Reason = ReasonConflict
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		apiErr.Reason = ReasonRateLimited
+	}
+	return apiErr
+}
+
+// IsAPIErrorReason returns whether err is an APIError with the reason.
+func IsAPIErrorReason(err error, reason APIErrorReason) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Reason == reason
+}
+
+// hasErrorCode checks whether an error code in the response body contains
+// the substring. IBM Cloud reports exceeded quotas with codes such as
+// "over_quota" alongside a 400 or 403 status, and resources with dependents
+// with codes such as "subnet_in_use" alongside a 409 status.
+func hasErrorCode(response *core.DetailedResponse, substring string) bool {
+	result, ok := response.GetResult().(map[string]interface{})
+	if !ok {
+		return false
+	}
+	details, ok := result["errors"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, detail := range details {
+		if detailMap, ok := detail.(map[string]interface{}); ok {
+			if code, ok := detailMap["code"].(string); ok && strings.Contains(code, substring) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package ibmcloud
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		response       *core.DetailedResponse
+		err            error
+		expectedReason APIErrorReason
+		expectedMsg    string
+		retryable      bool
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:           "no response",
+			err:            errors.New("connection refused"),
+			expectedReason: ReasonUnknown,
+			expectedMsg:    "connection refused",
+		},
+		{
+			name:           "not found",
+			response:       &core.DetailedResponse{StatusCode: http.StatusNotFound},
+			err:            errors.New("subnet not found"),
+			expectedReason: ReasonNotFound,
+			expectedMsg:    "subnet not found [status 404]",
+		},
+		{
+			name:           "unauthorized",
+			response:       &core.DetailedResponse{StatusCode: http.StatusUnauthorized},
+			err:            errors.New("unauthorized"),
+			expectedReason: ReasonForbidden,
+			expectedMsg:    "unauthorized [status 401] (verify the API key in IC_API_KEY is valid and has the access policies required for installation)",
+		},
+		{
+			name:           "forbidden",
+			response:       &core.DetailedResponse{StatusCode: http.StatusForbidden},
+			err:            errors.New("forbidden"),
+			expectedReason: ReasonForbidden,
+			expectedMsg:    "forbidden [status 403] (verify the API key in IC_API_KEY is valid and has the access policies required for installation)",
+		},
+		{
+			name: "quota exceeded",
+			response: &core.DetailedResponse{
+				StatusCode: http.StatusBadRequest,
+				Result: map[string]interface{}{
+					"errors": []interface{}{
+						map[string]interface{}{"code": "over_quota", "message": "quota exceeded"},
+					},
+				},
+			},
+			err:            errors.New("quota exceeded"),
+			expectedReason: ReasonQuotaExceeded,
+			expectedMsg:    "quota exceeded [status 400] (request a quota increase for the account or remove unused resources)",
+		},
+		{
+			name: "not found with trace id",
+			response: &core.DetailedResponse{
+				StatusCode: http.StatusNotFound,
+				Headers:    http.Header{"X-Request-Id": []string{"a1b2c3"}},
+			},
+			err:            errors.New("vpc not found"),
+			expectedReason: ReasonNotFound,
+			expectedMsg:    "vpc not found [status 404, trace a1b2c3]",
+		},
+		{
+			name: "forbidden with transaction id",
+			response: &core.DetailedResponse{
+				StatusCode: http.StatusForbidden,
+				Headers:    http.Header{"Transaction-Id": []string{"d4e5f6"}},
+			},
+			err:            errors.New("forbidden"),
+			expectedReason: ReasonForbidden,
+			expectedMsg:    "forbidden [status 403, trace d4e5f6] (verify the API key in IC_API_KEY is valid and has the access policies required for installation)",
+		},
+		{
+			name:           "conflict",
+			response:       &core.DetailedResponse{StatusCode: http.StatusConflict},
+			err:            errors.New("already exists"),
+			expectedReason: ReasonConflict,
+			expectedMsg:    "already exists [status 409]",
+		},
+		{
+			name: "resource in use",
+			response: &core.DetailedResponse{
+				StatusCode: http.StatusConflict,
+				Result: map[string]interface{}{
+					"errors": []interface{}{
+						map[string]interface{}{"code": "subnet_in_use", "message": "subnet is in use"},
+					},
+				},
+			},
+			err:            errors.New("subnet is in use"),
+			expectedReason: ReasonResourceInUse,
+			expectedMsg:    "subnet is in use [status 409]",
+		},
+		{
+			name:           "rate limited",
+			response:       &core.DetailedResponse{StatusCode: http.StatusTooManyRequests},
+			err:            errors.New("too many requests"),
+			expectedReason: ReasonRateLimited,
+			expectedMsg:    "too many requests [status 429] (the request was throttled by IBM Cloud, retry the operation later)",
+			retryable:      true,
+		},
+		{
+			name:           "server error",
+			response:       &core.DetailedResponse{StatusCode: http.StatusServiceUnavailable},
+			err:            errors.New("service unavailable"),
+			expectedReason: ReasonUnknown,
+			expectedMsg:    "service unavailable [status 503]",
+			retryable:      true,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			err := NewAPIError(tCase.response, tCase.err)
+			if tCase.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var apiErr *APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, tCase.expectedReason, apiErr.Reason)
+				assert.Equal(t, tCase.retryable, apiErr.Retryable())
+			}
+			assert.EqualError(t, err, tCase.expectedMsg)
+			assert.True(t, IsAPIErrorReason(err, tCase.expectedReason))
+			assert.ErrorIs(t, err, tCase.err)
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package ibmcloud
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/ibmcloud/validation"
@@ -15,6 +19,9 @@ import (
 
 const (
 	createNew = "<create new>"
+
+	// apiTimeout bounds each IBM Cloud API call made by the survey.
+	apiTimeout = 1 * time.Minute
 )
 
 // Platform collects IBM Cloud-specific configuration.
@@ -24,9 +31,193 @@ func Platform() (*ibmcloud.Platform, error) {
 		return nil, err
 	}
 
-	return &ibmcloud.Platform{
-		Region: region,
-	}, nil
+	if !HasCredentials() {
+		logrus.Warn("IC_API_KEY is not set, a new resource group and VPC will be created for the cluster")
+		return &ibmcloud.Platform{
+			Region: region,
+		}, nil
+	}
+
+	client, err := NewClient(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceGroup, err := selectResourceGroup(client)
+	if err != nil {
+		return nil, err
+	}
+
+	platform := &ibmcloud.Platform{
+		Region:            region,
+		ResourceGroupName: resourceGroup,
+	}
+	if err := selectNetwork(client, platform); err != nil {
+		return nil, err
+	}
+	return platform, nil
+}
+
+// selectResourceGroup prompts for an existing resource group to install the
+// cluster into. An empty name is returned when a new resource group should be
+// created.
+func selectResourceGroup(client API) (string, error) {
+	// Bound only the API call, the user may take any time to answer.
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	groups, err := client.GetResourceGroups(ctx)
+	cancel()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list resource groups")
+	}
+
+	options := make([]string, 0, len(groups))
+	for _, group := range groups {
+		options = append(options, *group.Name)
+	}
+	sort.Strings(options)
+	options = append([]string{createNew}, options...)
+
+	var selectedResourceGroup string
+	err = survey.Ask([]*survey.Question{
+		{
+			Prompt: &survey.Select{
+				Message: "Resource Group",
+				Help:    "The existing IBM Cloud resource group to install the cluster into. If a new resource group is created, it is named after the cluster's infrastructure ID and deleted with the cluster.",
+				Default: createNew,
+				Options: options,
+			},
+		},
+	}, &selectedResourceGroup)
+	if err != nil {
+		return "", err
+	}
+
+	if selectedResourceGroup == createNew {
+		return "", nil
+	}
+	return selectedResourceGroup, nil
+}
+
+// selectNetwork prompts for an existing VPC in the region and, when one is
+// chosen, for its control plane and compute subnets. The platform is left
+// unchanged when a new VPC should be created.
+func selectNetwork(client API, platform *ibmcloud.Platform) error {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	vpcs, err := client.GetVPCs(ctx, platform.Region)
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to list VPCs")
+	}
+	if len(vpcs) == 0 {
+		return nil
+	}
+
+	vpcsByName := make(map[string]vpcv1.VPC, len(vpcs))
+	options := make([]string, 0, len(vpcs))
+	for _, vpc := range vpcs {
+		vpcsByName[*vpc.Name] = vpc
+		options = append(options, *vpc.Name)
+	}
+	sort.Strings(options)
+	options = append([]string{createNew}, options...)
+
+	var selectedVPC string
+	err = survey.Ask([]*survey.Question{
+		{
+			Prompt: &survey.Select{
+				Message: "VPC",
+				Help:    "The existing VPC to install the cluster into. If a new VPC is created, the installer also creates its subnets and deletes both with the cluster.",
+				Default: createNew,
+				Options: options,
+			},
+		},
+	}, &selectedVPC)
+	if err != nil {
+		return err
+	}
+	if selectedVPC == createNew {
+		return nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), apiTimeout)
+	subnets, err := client.GetSubnets(ctx, platform.Region)
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to list subnets")
+	}
+	subnetNames := vpcSubnetNames(subnets, selectedVPC)
+	if len(subnetNames) == 0 {
+		return errors.Errorf("VPC %s has no subnets", selectedVPC)
+	}
+
+	controlPlaneSubnets, err := selectSubnets("Control Plane Subnets", "The existing subnets of the VPC to create the control plane machines in, ideally one in each of three zones.", subnetNames)
+	if err != nil {
+		return err
+	}
+	computeSubnets, err := selectSubnets("Compute Subnets", "The existing subnets of the VPC to create the compute machines in.", subnetNames)
+	if err != nil {
+		return err
+	}
+
+	platform.VPCName = selectedVPC
+	if vpc := vpcsByName[selectedVPC]; vpc.ResourceGroup != nil && vpc.ResourceGroup.Name != nil {
+		platform.NetworkResourceGroupName = *vpc.ResourceGroup.Name
+	}
+	platform.ControlPlaneSubnets = subnetConfigs(subnets, controlPlaneSubnets, platform.NetworkResourceGroupName)
+	platform.ComputeSubnets = subnetConfigs(subnets, computeSubnets, platform.NetworkResourceGroupName)
+	return nil
+}
+
+// subnetConfigs returns the install config entries of the selected subnets,
+// setting the resource group of those outside the network resource group.
+func subnetConfigs(subnets []vpcv1.Subnet, selected []string, networkResourceGroup string) []ibmcloud.Subnet {
+	resourceGroups := make(map[string]string, len(subnets))
+	for _, subnet := range subnets {
+		if subnet.Name != nil && subnet.ResourceGroup != nil && subnet.ResourceGroup.Name != nil {
+			resourceGroups[*subnet.Name] = *subnet.ResourceGroup.Name
+		}
+	}
+
+	configs := make([]ibmcloud.Subnet, 0, len(selected))
+	for _, name := range selected {
+		config := ibmcloud.Subnet{Name: name}
+		if resourceGroup := resourceGroups[name]; resourceGroup != networkResourceGroup {
+			config.ResourceGroupName = resourceGroup
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// selectSubnets prompts for one or more of the subnets.
+func selectSubnets(message string, help string, subnetNames []string) ([]string, error) {
+	var selectedSubnets []string
+	err := survey.Ask([]*survey.Question{
+		{
+			Prompt: &survey.MultiSelect{
+				Message: message,
+				Help:    help,
+				Options: subnetNames,
+			},
+			Validate: survey.Required,
+		},
+	}, &selectedSubnets)
+	if err != nil {
+		return nil, err
+	}
+	return selectedSubnets, nil
+}
+
+// vpcSubnetNames returns the sorted names of the subnets in the VPC.
+func vpcSubnetNames(subnets []vpcv1.Subnet, vpcName string) []string {
+	var names []string
+	for _, subnet := range subnets {
+		if subnet.VPC != nil && subnet.VPC.Name != nil && *subnet.VPC.Name == vpcName {
+			names = append(names, *subnet.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 func selectRegion() (string, error) {
@@ -49,7 +240,7 @@ func selectRegion() (string, error) {
 	sort.Strings(longRegions)
 	sort.Strings(shortRegions)
 
-	defaultRegion := longRegions[0]
+	defaultRegion := shortRegions[0]
 
 	var selectedRegion string
 	err := survey.Ask([]*survey.Question{
@@ -0,0 +1,47 @@
+package ibmcloud
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+
+	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+func TestVPCSubnetNames(t *testing.T) {
+	subnet := func(name string, vpc string) vpcv1.Subnet {
+		return vpcv1.Subnet{
+			Name: core.StringPtr(name),
+			VPC:  &vpcv1.VPCReference{Name: core.StringPtr(vpc)},
+		}
+	}
+	subnets := []vpcv1.Subnet{
+		subnet("subnet-b", "valid-vpc"),
+		subnet("subnet-other", "other-vpc"),
+		subnet("subnet-a", "valid-vpc"),
+		{Name: core.StringPtr("subnet-no-vpc")},
+	}
+
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, vpcSubnetNames(subnets, "valid-vpc"))
+	assert.Empty(t, vpcSubnetNames(subnets, "missing-vpc"))
+}
+
+func TestSubnetConfigs(t *testing.T) {
+	subnet := func(name string, resourceGroup string) vpcv1.Subnet {
+		return vpcv1.Subnet{
+			Name:          core.StringPtr(name),
+			ResourceGroup: &vpcv1.ResourceGroupReference{Name: core.StringPtr(resourceGroup)},
+		}
+	}
+	subnets := []vpcv1.Subnet{
+		subnet("subnet-a", "network-rg"),
+		subnet("subnet-b", "compute-rg"),
+	}
+
+	assert.Equal(t, []ibmcloudtypes.Subnet{
+		{Name: "subnet-a"},
+		{Name: "subnet-b", ResourceGroupName: "compute-rg"},
+	}, subnetConfigs(subnets, []string{"subnet-a", "subnet-b"}, "network-rg"))
+}
@@ -0,0 +1,62 @@
+package ibmcloud
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// metadataLookups is the process-level cache shared by all Metadata, so
+// assets generated concurrently from the same install config do not repeat
+// the IAM, DNS Services and Cloud Internet Services lookups.
+var metadataLookups = newLookupCache()
+
+// lookupCache holds the results of IBM Cloud API lookups by key.
+type lookupCache struct {
+	group   singleflight.Group
+	mutex   sync.Mutex
+	results map[string]interface{}
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{results: map[string]interface{}{}}
+}
+
+// get returns the cached result for the key. Otherwise, lookup is called
+// once for all concurrent callers and its result is cached. Errors are not
+// cached, so a failed lookup is retried by the next caller.
+func (c *lookupCache) get(key string, lookup func() (interface{}, error)) (interface{}, error) {
+	c.mutex.Lock()
+	result, ok := c.results[key]
+	c.mutex.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		result, err := lookup()
+		if err != nil {
+			return nil, err
+		}
+		c.mutex.Lock()
+		c.results[key] = result
+		c.mutex.Unlock()
+		return result, nil
+	})
+	return result, err
+}
+
+// installConfigHash returns the hash keying the lookups for an install
+// config, or an empty string if it cannot be hashed.
+func installConfigHash(config *types.InstallConfig) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
@@ -7,25 +7,33 @@ import (
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
 // Metadata holds additional metadata for InstallConfig resources that
 // does not need to be user-supplied (e.g. because it can be retrieved
 // from external APIs).
 type Metadata struct {
-	BaseDomain              string
-	ComputeSubnetNames      []string
-	ControlPlaneSubnetNames []string
-	Region                  string
+	BaseDomain                string
+	ComputeSubnetConfigs      []ibmcloud.Subnet
+	ControlPlaneSubnetConfigs []ibmcloud.Subnet
+	NetworkResourceGroupName  string
+	Region                    string
+	ServiceEndpoints          []ibmcloud.ServiceEndpoint
+	VPCName                   string
 
 	accountID           string
 	cisInstanceCRN      string
 	client              API
 	computeSubnets      map[string]Subnet
+	configHash          string
 	controlPlaneSubnets map[string]Subnet
 	dnsInstance         *DNSInstance
+	lookups             *lookupCache
+	vpcZones            []string
 
 	mutex       sync.Mutex
 	clientMutex sync.Mutex
@@ -38,16 +46,31 @@ type DNSInstance struct {
 	Zone string
 }
 
-// NewMetadata initializes a new Metadata object.
-func NewMetadata(baseDomain string, region string, controlPlaneSubnets []string, computeSubnets []string) *Metadata {
+// NewMetadata initializes a new Metadata object from the install config.
+func NewMetadata(config *types.InstallConfig) *Metadata {
 	return &Metadata{
-		BaseDomain:              baseDomain,
-		ComputeSubnetNames:      computeSubnets,
-		ControlPlaneSubnetNames: controlPlaneSubnets,
-		Region:                  region,
+		BaseDomain:                config.BaseDomain,
+		ComputeSubnetConfigs:      config.IBMCloud.ComputeSubnets,
+		ControlPlaneSubnetConfigs: config.IBMCloud.ControlPlaneSubnets,
+		NetworkResourceGroupName:  config.IBMCloud.NetworkResourceGroupName,
+		Region:                    config.IBMCloud.Region,
+		ServiceEndpoints:          config.IBMCloud.ServiceEndpoints,
+		VPCName:                   config.IBMCloud.VPCName,
+
+		configHash: installConfigHash(config),
+		lookups:    metadataLookups,
 	}
 }
 
+// lookup returns the result of a lookup for the install config, shared with
+// other Metadata built from the same install config.
+func (m *Metadata) lookup(name string, lookup func() (interface{}, error)) (interface{}, error) {
+	if m.lookups == nil || m.configHash == "" {
+		return lookup()
+	}
+	return m.lookups.get(fmt.Sprintf("%s/%s", m.configHash, name), lookup)
+}
+
 // AccountID returns the IBM Cloud account ID associated with the authentication
 // credentials.
 func (m *Metadata) AccountID(ctx context.Context) (string, error) {
@@ -55,17 +78,22 @@ func (m *Metadata) AccountID(ctx context.Context) (string, error) {
 	defer m.mutex.Unlock()
 
 	if m.accountID == "" {
-		client, err := m.Client()
-		if err != nil {
-			return "", err
-		}
+		accountID, err := m.lookup("accountID", func() (interface{}, error) {
+			client, err := m.Client()
+			if err != nil {
+				return nil, err
+			}
 
-		apiKeyDetails, err := client.GetAuthenticatorAPIKeyDetails(ctx)
+			apiKeyDetails, err := client.GetAuthenticatorAPIKeyDetails(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return *apiKeyDetails.AccountID, nil
+		})
 		if err != nil {
 			return "", err
 		}
-
-		m.accountID = *apiKeyDetails.AccountID
+		m.accountID = accountID.(string)
 	}
 	return m.accountID, nil
 }
@@ -77,23 +105,28 @@ func (m *Metadata) CISInstanceCRN(ctx context.Context) (string, error) {
 	defer m.mutex.Unlock()
 
 	if m.cisInstanceCRN == "" {
-		client, err := m.Client()
-		if err != nil {
-			return "", err
-		}
+		cisInstanceCRN, err := m.lookup("cisInstanceCRN", func() (interface{}, error) {
+			client, err := m.Client()
+			if err != nil {
+				return nil, err
+			}
 
-		zones, err := client.GetDNSZones(ctx, types.ExternalPublishingStrategy)
-		if err != nil {
-			return "", err
-		}
+			zones, err := client.GetDNSZones(ctx, types.ExternalPublishingStrategy)
+			if err != nil {
+				return nil, err
+			}
 
-		for _, z := range zones {
-			if z.Name == m.BaseDomain {
-				m.cisInstanceCRN = z.InstanceCRN
-				return m.cisInstanceCRN, nil
+			for _, z := range zones {
+				if z.Name == m.BaseDomain {
+					return z.InstanceCRN, nil
+				}
 			}
+			return nil, fmt.Errorf("cisInstanceCRN unknown due to DNS zone %q not found", m.BaseDomain)
+		})
+		if err != nil {
+			return "", err
 		}
-		return "", fmt.Errorf("cisInstanceCRN unknown due to DNS zone %q not found", m.BaseDomain)
+		m.cisInstanceCRN = cisInstanceCRN.(string)
 	}
 	return m.cisInstanceCRN, nil
 }
@@ -101,39 +134,39 @@ func (m *Metadata) CISInstanceCRN(ctx context.Context) (string, error) {
 // DNSInstance returns a DNSInstance holding information about the DNS Services instance
 // managing the DNS zone for the base domain.
 func (m *Metadata) DNSInstance(ctx context.Context) (*DNSInstance, error) {
-	if m.dnsInstance != nil {
-		return m.dnsInstance, nil
-	}
-
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	// Prevent multiple attempts to retrieve (set) the dnsInstance if it hasn't been set (multiple threads reach mutex concurrently)
 	if m.dnsInstance == nil {
-		client, err := m.Client()
-		if err != nil {
-			return nil, err
-		}
+		dnsInstance, err := m.lookup("dnsInstance", func() (interface{}, error) {
+			client, err := m.Client()
+			if err != nil {
+				return nil, err
+			}
 
-		zones, err := client.GetDNSZones(ctx, types.InternalPublishingStrategy)
-		if err != nil {
-			return nil, err
-		}
+			zones, err := client.GetDNSZones(ctx, types.InternalPublishingStrategy)
+			if err != nil {
+				return nil, err
+			}
 
-		for _, z := range zones {
-			if z.Name == m.BaseDomain {
-				if z.InstanceID == "" || z.InstanceCRN == "" {
-					return nil, fmt.Errorf("dnsInstance has unknown ID/CRN: %q - %q", z.InstanceID, z.InstanceCRN)
-				}
-				m.dnsInstance = &DNSInstance{
-					ID:   z.InstanceID,
-					CRN:  z.InstanceCRN,
-					Zone: z.ID,
+			for _, z := range zones {
+				if z.Name == m.BaseDomain {
+					if z.InstanceID == "" || z.InstanceCRN == "" {
+						return nil, fmt.Errorf("dnsInstance has unknown ID/CRN: %q - %q", z.InstanceID, z.InstanceCRN)
+					}
+					return &DNSInstance{
+						ID:   z.InstanceID,
+						CRN:  z.InstanceCRN,
+						Zone: z.ID,
+					}, nil
 				}
-				return m.dnsInstance, nil
 			}
+			return nil, fmt.Errorf("dnsInstance unknown due to DNS zone %q not found", m.BaseDomain)
+		})
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("dnsInstance unknown due to DNS zone %q not found", m.BaseDomain)
+		m.dnsInstance = dnsInstance.(*DNSInstance)
 	}
 	return m.dnsInstance, nil
 }
@@ -145,11 +178,9 @@ func (m *Metadata) IsVPCPermittedNetwork(ctx context.Context, vpcName string) (b
 		return false, nil
 	}
 	// Collect DNSInstance details if not already collected
-	if m.dnsInstance == nil {
-		_, err := m.DNSInstance(ctx)
-		if err != nil {
-			return false, errors.Wrap(err, "cannot collect DNS permitted networks without DNS Instance")
-		}
+	dnsInstance, err := m.DNSInstance(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot collect DNS permitted networks without DNS Instance")
 	}
 
 	client, err := m.Client()
@@ -157,7 +188,7 @@ func (m *Metadata) IsVPCPermittedNetwork(ctx context.Context, vpcName string) (b
 		return false, err
 	}
 
-	networks, err := client.GetDNSInstancePermittedNetworks(ctx, m.dnsInstance.ID, m.dnsInstance.Zone)
+	networks, err := client.GetDNSInstancePermittedNetworks(ctx, dnsInstance.ID, dnsInstance.Zone)
 	if err != nil {
 		return false, err
 	}
@@ -165,7 +196,10 @@ func (m *Metadata) IsVPCPermittedNetwork(ctx context.Context, vpcName string) (b
 		return false, nil
 	}
 
-	vpc, err := client.GetVPCByName(ctx, vpcName)
+	vpc, err := client.GetVPCByName(ctx, vpcName, m.NetworkResourceGroupName)
+	if err != nil {
+		return false, err
+	}
 	for _, network := range networks {
 		if network == *vpc.CRN {
 			return true, nil
@@ -180,12 +214,12 @@ func (m *Metadata) ComputeSubnets(ctx context.Context) (map[string]Subnet, error
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if len(m.ComputeSubnetNames) > 0 && len(m.computeSubnets) == 0 {
+	if len(m.ComputeSubnetConfigs) > 0 && len(m.computeSubnets) == 0 {
 		client, err := m.Client()
 		if err != nil {
 			return nil, err
 		}
-		m.computeSubnets, err = getSubnets(ctx, client, m.Region, m.ComputeSubnetNames)
+		m.computeSubnets, err = getSubnets(ctx, client, m.Region, m.VPCName, m.NetworkResourceGroupName, m.ComputeSubnetConfigs)
 		if err != nil {
 			return nil, err
 		}
@@ -199,12 +233,12 @@ func (m *Metadata) ControlPlaneSubnets(ctx context.Context) (map[string]Subnet,
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if len(m.ControlPlaneSubnetNames) > 0 && len(m.controlPlaneSubnets) == 0 {
+	if len(m.ControlPlaneSubnetConfigs) > 0 && len(m.controlPlaneSubnets) == 0 {
 		client, err := m.Client()
 		if err != nil {
 			return nil, err
 		}
-		m.controlPlaneSubnets, err = getSubnets(ctx, client, m.Region, m.ControlPlaneSubnetNames)
+		m.controlPlaneSubnets, err = getSubnets(ctx, client, m.Region, m.VPCName, m.NetworkResourceGroupName, m.ControlPlaneSubnetConfigs)
 		if err != nil {
 			return nil, err
 		}
@@ -213,6 +247,37 @@ func (m *Metadata) ControlPlaneSubnets(ctx context.Context) (map[string]Subnet,
 	return m.controlPlaneSubnets, nil
 }
 
+// VPCZones returns the available zones for VPC resources in the region,
+// used as the default zones of machine pools. The zones are retrieved once
+// and cached for subsequent calls.
+func (m *Metadata) VPCZones(ctx context.Context) ([]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(m.vpcZones) == 0 && m.client == nil && !HasCredentials() {
+		entry, ok := ibmcloud.CatalogRegion(m.Region)
+		if !ok {
+			return nil, fmt.Errorf("no IBM Cloud credentials and region %q is not in the region catalog", m.Region)
+		}
+		logrus.Warnf("IC_API_KEY is not set, using the zones of region %s from the region catalog", m.Region)
+		m.vpcZones = entry.Zones
+	}
+
+	if len(m.vpcZones) == 0 {
+		client, err := m.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		zones, err := client.GetAvailableVPCZonesForRegion(ctx, m.Region)
+		if err != nil {
+			return nil, err
+		}
+		m.vpcZones = zones
+	}
+	return m.vpcZones, nil
+}
+
 // Client returns a client used for making API calls to IBM Cloud services.
 func (m *Metadata) Client() (API, error) {
 	if m.client != nil {
@@ -222,7 +287,7 @@ func (m *Metadata) Client() (API, error) {
 	m.clientMutex.Lock()
 	defer m.clientMutex.Unlock()
 
-	client, err := NewClient()
+	client, err := NewClient(m.ServiceEndpoints)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package ibmcloud
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/IBM/platform-services-go-sdk/iamidentityv1"
@@ -13,6 +14,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
 	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/responses"
 	"github.com/openshift/installer/pkg/types"
+	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
 type editMetadata []func(m *Metadata)
@@ -156,6 +158,10 @@ var (
 	noVPCComputeSubnetID        = "no-vpc-compute-subnet-id"
 	noZoneComputeSubnetID       = "no-zone-compute-subnet-id"
 
+	// Subnet in another resource group than the network resource group.
+	otherRGComputeSubnetName = "other-rg-compute-subnet"
+	otherRGComputeSubnetRG   = "compute-resource-group"
+
 	// VPCReferences for Client Subnet responses.
 	vpcReferenceComputeSubnet1      = vpcv1.VPCReference{Name: &newComputeSubnet1VPCName}
 	vpcReferenceComputeSubnet2      = vpcv1.VPCReference{Name: &newComputeSubnet2VPCName}
@@ -169,8 +175,23 @@ var (
 	zoneReferenceControlPlaneSubnet2 = vpcv1.ZoneReference{Name: &newControlPlaneSubnet2ZoneName}
 )
 
+func baseInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		BaseDomain: goodDomain,
+		Platform: types.Platform{
+			IBMCloud: &ibmcloudtypes.Platform{
+				Region: region,
+			},
+		},
+	}
+}
+
+// baseMetadata returns Metadata with its own lookup cache, so test cases do
+// not see the lookups of each other.
 func baseMetadata() *Metadata {
-	return NewMetadata(goodDomain, region, nil, nil)
+	metadata := NewMetadata(baseInstallConfig())
+	metadata.lookups = newLookupCache()
+	return metadata
 }
 
 func TestAccountID(t *testing.T) {
@@ -414,6 +435,80 @@ func TestDNSInstance(t *testing.T) {
 	}
 }
 
+func TestDNSInstanceConcurrent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// The DNS zones are only listed once for concurrent lookups.
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return([]responses.DNSZoneResponse{{Name: goodDomain, InstanceID: newDNSInstanceID, InstanceCRN: newDNSInstanceCRN}}, nil).Times(1)
+
+	metadata := baseMetadata()
+	metadata.client = ibmcloudClient
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actualDNS, err := metadata.DNSInstance(context.TODO())
+			if assert.NoError(t, err) {
+				assert.Equal(t, newDNSInstanceID, actualDNS.ID)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSharedLookups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// The lookups are only made once for Metadata of the same install config.
+	ibmcloudClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(&iamidentityv1.APIKey{AccountID: &newAccountID}, nil).Times(1)
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.ExternalPublishingStrategy).Return([]responses.DNSZoneResponse{{Name: goodDomain, InstanceCRN: newCISCRN}}, nil).Times(1)
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return([]responses.DNSZoneResponse{{Name: goodDomain, InstanceID: newDNSInstanceID, InstanceCRN: newDNSInstanceCRN}}, nil).Times(1)
+
+	lookups := newLookupCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		metadata := NewMetadata(baseInstallConfig())
+		metadata.client = ibmcloudClient
+		metadata.lookups = lookups
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accountID, err := metadata.AccountID(context.TODO())
+			if assert.NoError(t, err) {
+				assert.Equal(t, newAccountID, accountID)
+			}
+			cisCRN, err := metadata.CISInstanceCRN(context.TODO())
+			if assert.NoError(t, err) {
+				assert.Equal(t, newCISCRN, cisCRN)
+			}
+			actualDNS, err := metadata.DNSInstance(context.TODO())
+			if assert.NoError(t, err) {
+				assert.Equal(t, newDNSInstanceID, actualDNS.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A different install config does its own lookups.
+	other := baseInstallConfig()
+	other.BaseDomain = badDomain
+	metadata := NewMetadata(other)
+	metadata.client = ibmcloudClient
+	metadata.lookups = lookups
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.ExternalPublishingStrategy).Return([]responses.DNSZoneResponse{{Name: badDomain, InstanceCRN: existingCISCRN}}, nil).Times(1)
+	cisCRN, err := metadata.CISInstanceCRN(context.TODO())
+	if assert.NoError(t, err) {
+		assert.Equal(t, existingCISCRN, cisCRN)
+	}
+}
+
 func TestSetDNSInstance(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -458,7 +553,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "new compute subnets",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{newComputeSubnet1Name, newComputeSubnet2Name}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: newComputeSubnet1Name}, {Name: newComputeSubnet2Name}}
 				},
 			},
 			expectedValue: newComputeSubnets,
@@ -467,7 +562,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "new single compute subnet",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{newComputeSubnet2Name}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: newComputeSubnet2Name}}
 				},
 			},
 			expectedValue: map[string]Subnet{
@@ -487,7 +582,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "failed getting compute subnet",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{failedGetComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: failedGetComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("getting subnet %s", failedGetComputeSubnetName),
@@ -496,7 +591,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no id",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{noIDComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: noIDComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no ID", noIDComputeSubnetName),
@@ -505,7 +600,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no CIDR block",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{incompleteComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: incompleteComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no Ipv4CIDRBlock", noCIDRComputeSubnetID),
@@ -514,7 +609,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no CRN",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{incompleteComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: incompleteComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no CRN", noCRNComputeSubnetID),
@@ -523,7 +618,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no Name",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{incompleteComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: incompleteComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no Name", noNameComputeSubnetID),
@@ -532,7 +627,7 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no VPC",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{incompleteComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: incompleteComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no VPC", noVPCComputeSubnetID),
@@ -541,11 +636,40 @@ func TestComputeSubnets(t *testing.T) {
 			name: "compute subnet has no Zone",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ComputeSubnetNames = []string{incompleteComputeSubnetName}
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: incompleteComputeSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("%s has no Zone", noZoneComputeSubnetID),
 		},
+		{
+			name: "compute subnet in its resource group",
+			edits: editMetadata{
+				func(m *Metadata) {
+					m.NetworkResourceGroupName = "network-resource-group"
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: otherRGComputeSubnetName, ResourceGroupName: otherRGComputeSubnetRG}}
+				},
+			},
+			expectedValue: map[string]Subnet{
+				newComputeSubnet1ID: {
+					Name: otherRGComputeSubnetName,
+					ID:   newComputeSubnet1ID,
+					CIDR: newComputeSubnet1CIDR,
+					CRN:  newComputeSubnet1CRN,
+					VPC:  newComputeSubnet1VPCName,
+					Zone: newComputeSubnet1ZoneName,
+				},
+			},
+		},
+		{
+			name: "compute subnet not in network resource group",
+			edits: editMetadata{
+				func(m *Metadata) {
+					m.NetworkResourceGroupName = "network-resource-group"
+					m.ComputeSubnetConfigs = []ibmcloudtypes.Subnet{{Name: otherRGComputeSubnetName}}
+				},
+			},
+			errorMsg: fmt.Sprintf("%s is not in resource group network-resource-group", otherRGComputeSubnetName),
+		},
 	}
 
 	// IBM Cloud Client Mocks.
@@ -560,7 +684,7 @@ func TestComputeSubnets(t *testing.T) {
 	// N/A.
 
 	// Mocks: new compute subnets.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet1Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet1Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newComputeSubnet1Name,
 			ID:            &newComputeSubnet1ID,
@@ -571,7 +695,7 @@ func TestComputeSubnets(t *testing.T) {
 		},
 		nil,
 	)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet2Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet2Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newComputeSubnet2Name,
 			ID:            &newComputeSubnet2ID,
@@ -584,7 +708,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: new single compute subnet.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet2Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newComputeSubnet2Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newComputeSubnet2Name,
 			ID:            &newComputeSubnet2ID,
@@ -600,10 +724,10 @@ func TestComputeSubnets(t *testing.T) {
 	// N/A.
 
 	// Mocks: failed getting compute subnet.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), failedGetComputeSubnetName, region).Return(nil, &VPCResourceNotFoundError{})
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), failedGetComputeSubnetName, region, "").Return(nil, &VPCResourceNotFoundError{})
 
 	// Mocks: compute subnet has no ID.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), noIDComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), noIDComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			Name: &noIDComputeSubnetName,
 			// ID Skipped.
@@ -616,7 +740,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: compute subnet has no CIDR.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			Name: &incompleteComputeSubnetName,
 			ID:   &noCIDRComputeSubnetID,
@@ -629,7 +753,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: compute subnet has no CRN.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &incompleteComputeSubnetName,
 			ID:            &noCRNComputeSubnetID,
@@ -642,7 +766,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: compute subnet has no Name.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			// Name Skipped.
 			ID:            &noNameComputeSubnetID,
@@ -655,7 +779,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: compute subnet has no VPC.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &incompleteComputeSubnetName,
 			ID:            &noVPCComputeSubnetID,
@@ -668,7 +792,7 @@ func TestComputeSubnets(t *testing.T) {
 	)
 
 	// Mocks: compute subnet has no Zone.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), incompleteComputeSubnetName, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &incompleteComputeSubnetName,
 			ID:            &noZoneComputeSubnetID,
@@ -680,6 +804,24 @@ func TestComputeSubnets(t *testing.T) {
 		nil,
 	)
 
+	// Mocks: compute subnet in its resource group.
+	// Mocks: compute subnet not in network resource group.
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), otherRGComputeSubnetName, region, "").Return(
+		&vpcv1.Subnet{
+			Name:          &otherRGComputeSubnetName,
+			ID:            &newComputeSubnet1ID,
+			Ipv4CIDRBlock: &newComputeSubnet1CIDR,
+			CRN:           &newComputeSubnet1CRN,
+			VPC:           &vpcReferenceComputeSubnet1,
+			Zone:          &zoneReferenceComputeSubnet1,
+			ResourceGroup: &vpcv1.ResourceGroupReference{
+				ID:   &otherRGComputeSubnetRG,
+				Name: &otherRGComputeSubnetRG,
+			},
+		},
+		nil,
+	).Times(2)
+
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
 			metadata := baseMetadata()
@@ -714,7 +856,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 			name: "new control plane subnets",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ControlPlaneSubnetNames = []string{newControlPlaneSubnet1Name, newControlPlaneSubnet2Name}
+					m.ControlPlaneSubnetConfigs = []ibmcloudtypes.Subnet{{Name: newControlPlaneSubnet1Name}, {Name: newControlPlaneSubnet2Name}}
 				},
 			},
 			expectedValue: newControlPlaneSubnets,
@@ -723,7 +865,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 			name: "new single control plane subnet",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ControlPlaneSubnetNames = []string{newControlPlaneSubnet2Name}
+					m.ControlPlaneSubnetConfigs = []ibmcloudtypes.Subnet{{Name: newControlPlaneSubnet2Name}}
 				},
 			},
 			expectedValue: map[string]Subnet{
@@ -743,7 +885,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 			name: "failed getting control plane subnet",
 			edits: editMetadata{
 				func(m *Metadata) {
-					m.ControlPlaneSubnetNames = []string{failedGetControlPlaneSubnetName}
+					m.ControlPlaneSubnetConfigs = []ibmcloudtypes.Subnet{{Name: failedGetControlPlaneSubnetName}}
 				},
 			},
 			errorMsg: fmt.Sprintf("getting subnet %v", failedGetControlPlaneSubnetName),
@@ -762,7 +904,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 	// N/A.
 
 	// Mocks: new control plane subnets.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet1Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet1Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newControlPlaneSubnet1Name,
 			ID:            &newControlPlaneSubnet1ID,
@@ -773,7 +915,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 		},
 		nil,
 	)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet2Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet2Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newControlPlaneSubnet2Name,
 			ID:            &newControlPlaneSubnet2ID,
@@ -786,7 +928,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 	)
 
 	// Mocks: new single control plane subnet.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet2Name, region).Return(
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), newControlPlaneSubnet2Name, region, "").Return(
 		&vpcv1.Subnet{
 			Name:          &newControlPlaneSubnet2Name,
 			ID:            &newControlPlaneSubnet2ID,
@@ -802,7 +944,7 @@ func TestControlPlaneSubnets(t *testing.T) {
 	// N/A.
 
 	// Mocks: failed getting control plane subnet.
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), failedGetControlPlaneSubnetName, region).Return(nil, &VPCResourceNotFoundError{})
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), failedGetControlPlaneSubnetName, region, "").Return(nil, &VPCResourceNotFoundError{})
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
@@ -861,3 +1003,136 @@ func TestClient(t *testing.T) {
 		})
 	}
 }
+
+func TestVPCZones(t *testing.T) {
+	testCases := []struct {
+		name          string
+		edits         editMetadata
+		errorMsg      string
+		expectedValue []string
+	}{
+		{
+			name:          "new vpc zones",
+			expectedValue: []string{"us-south-1", "us-south-2", "us-south-3"},
+		},
+		{
+			name: "existing vpc zones",
+			edits: editMetadata{
+				func(m *Metadata) {
+					m.vpcZones = []string{"us-south-2"}
+				},
+			},
+			expectedValue: []string{"us-south-2"},
+		},
+		{
+			name:     "get vpc zones error",
+			errorMsg: "zones error",
+		},
+	}
+
+	// IBM Cloud Client Mocks.
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Shared Mocks.
+	ibmcloudClient.EXPECT().SetVPCServiceURLForRegion(gomock.Any(), "us-south").AnyTimes()
+
+	// Mocks: new vpc zones.
+	ibmcloudClient.EXPECT().GetAvailableVPCZonesForRegion(gomock.Any(), region).Return([]string{"us-south-1", "us-south-2", "us-south-3"}, nil)
+
+	// Mocks: existing vpc zones.
+	// N/A.
+
+	// Mocks: get vpc zones error.
+	ibmcloudClient.EXPECT().GetAvailableVPCZonesForRegion(gomock.Any(), region).Return(nil, fmt.Errorf("zones error"))
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			metadata := baseMetadata()
+			metadata.client = ibmcloudClient
+			for _, edit := range tCase.edits {
+				edit(metadata)
+			}
+
+			actualValue, err := metadata.VPCZones(context.TODO())
+			if err != nil {
+				assert.Regexp(t, tCase.errorMsg, err)
+			} else {
+				assert.Equal(t, tCase.expectedValue, actualValue)
+				// Subsequent calls use the cached zones.
+				cachedValue, err := metadata.VPCZones(context.TODO())
+				assert.NoError(t, err)
+				assert.Equal(t, tCase.expectedValue, cachedValue)
+			}
+		})
+	}
+}
+
+func TestVPCZonesWithoutCredentials(t *testing.T) {
+	t.Setenv("IC_API_KEY", "")
+
+	metadata := baseMetadata()
+	zones, err := metadata.VPCZones(context.TODO())
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"us-south-1", "us-south-2", "us-south-3"}, zones)
+	}
+
+	metadata = baseMetadata()
+	metadata.Region = "us-west"
+	_, err = metadata.VPCZones(context.TODO())
+	assert.EqualError(t, err, `no IBM Cloud credentials and region "us-west" is not in the region catalog`)
+}
+
+func TestValidateSubnetTopology(t *testing.T) {
+	subnets := map[string]Subnet{
+		"subnet-id-1": {ID: "subnet-id-1", Name: "subnet-1", VPC: "vpc-1", Zone: "us-south-1"},
+		"subnet-id-2": {ID: "subnet-id-2", Name: "subnet-2", VPC: "vpc-1", Zone: "us-south-2"},
+	}
+
+	testCases := []struct {
+		name     string
+		subnets  map[string]Subnet
+		vpcName  string
+		zones    []string
+		errorMsg string
+	}{
+		{
+			name:    "valid topology",
+			subnets: subnets,
+			vpcName: "vpc-1",
+			zones:   []string{"us-south-1", "us-south-2"},
+		},
+		{
+			name:    "valid topology, subset of zones",
+			subnets: subnets,
+			vpcName: "vpc-1",
+			zones:   []string{"us-south-2"},
+		},
+		{
+			name:     "subnet in other vpc",
+			subnets:  subnets,
+			vpcName:  "vpc-2",
+			zones:    []string{"us-south-1"},
+			errorMsg: `^subnet subnet-[12] is in VPC vpc-1, expected VPC vpc-2$`,
+		},
+		{
+			name:     "zones not covered",
+			subnets:  subnets,
+			vpcName:  "vpc-1",
+			zones:    []string{"us-south-1", "us-south-2", "us-south-3"},
+			errorMsg: `^no subnet provided for zones: us-south-3$`,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			err := ValidateSubnetTopology(tCase.subnets, tCase.vpcName, tCase.zones)
+			if tCase.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tCase.errorMsg, err)
+			}
+		})
+	}
+}
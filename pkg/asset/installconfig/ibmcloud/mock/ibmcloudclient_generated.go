@@ -55,6 +55,21 @@ func (mr *MockAPIMockRecorder) GetAPIKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPI)(nil).GetAPIKey))
 }
 
+// GetAttachedTags mocks base method.
+func (m *MockAPI) GetAttachedTags(ctx context.Context, crn string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachedTags", ctx, crn)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachedTags indicates an expected call of GetAttachedTags.
+func (mr *MockAPIMockRecorder) GetAttachedTags(ctx, crn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachedTags", reflect.TypeOf((*MockAPI)(nil).GetAttachedTags), ctx, crn)
+}
+
 // GetAuthenticatorAPIKeyDetails mocks base method.
 func (m *MockAPI) GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +85,21 @@ func (mr *MockAPIMockRecorder) GetAuthenticatorAPIKeyDetails(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthenticatorAPIKeyDetails", reflect.TypeOf((*MockAPI)(nil).GetAuthenticatorAPIKeyDetails), ctx)
 }
 
+// GetAvailableVPCZonesForRegion mocks base method.
+func (m *MockAPI) GetAvailableVPCZonesForRegion(ctx context.Context, region string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableVPCZonesForRegion", ctx, region)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableVPCZonesForRegion indicates an expected call of GetAvailableVPCZonesForRegion.
+func (mr *MockAPIMockRecorder) GetAvailableVPCZonesForRegion(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableVPCZonesForRegion", reflect.TypeOf((*MockAPI)(nil).GetAvailableVPCZonesForRegion), ctx, region)
+}
+
 // GetCISInstance mocks base method.
 func (m *MockAPI) GetCISInstance(ctx context.Context, crnstr string) (*resourcecontrollerv2.ResourceInstance, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +235,36 @@ func (mr *MockAPIMockRecorder) GetEncryptionKey(ctx, keyCRN interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncryptionKey", reflect.TypeOf((*MockAPI)(nil).GetEncryptionKey), ctx, keyCRN)
 }
 
+// GetImageByName mocks base method.
+func (m *MockAPI) GetImageByName(ctx context.Context, region, name string) (*vpcv1.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageByName", ctx, region, name)
+	ret0, _ := ret[0].(*vpcv1.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageByName indicates an expected call of GetImageByName.
+func (mr *MockAPIMockRecorder) GetImageByName(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageByName", reflect.TypeOf((*MockAPI)(nil).GetImageByName), ctx, region, name)
+}
+
+// GetLoadBalancers mocks base method.
+func (m *MockAPI) GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancers", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancers indicates an expected call of GetLoadBalancers.
+func (mr *MockAPIMockRecorder) GetLoadBalancers(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancers", reflect.TypeOf((*MockAPI)(nil).GetLoadBalancers), ctx, region)
+}
+
 // GetResourceGroup mocks base method.
 func (m *MockAPI) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +295,21 @@ func (mr *MockAPIMockRecorder) GetResourceGroups(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceGroups", reflect.TypeOf((*MockAPI)(nil).GetResourceGroups), ctx)
 }
 
+// GetSecurityGroups mocks base method.
+func (m *MockAPI) GetSecurityGroups(ctx context.Context, region, vpcID string) ([]vpcv1.SecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroups", ctx, region, vpcID)
+	ret0, _ := ret[0].([]vpcv1.SecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityGroups indicates an expected call of GetSecurityGroups.
+func (mr *MockAPIMockRecorder) GetSecurityGroups(ctx, region, vpcID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroups", reflect.TypeOf((*MockAPI)(nil).GetSecurityGroups), ctx, region, vpcID)
+}
+
 // GetSubnet mocks base method.
 func (m *MockAPI) GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -251,18 +326,33 @@ func (mr *MockAPIMockRecorder) GetSubnet(ctx, subnetID interface{}) *gomock.Call
 }
 
 // GetSubnetByName mocks base method.
-func (m *MockAPI) GetSubnetByName(ctx context.Context, subnetName, region string) (*vpcv1.Subnet, error) {
+func (m *MockAPI) GetSubnetByName(ctx context.Context, subnetName, region, vpc string) (*vpcv1.Subnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubnetByName", ctx, subnetName, region)
+	ret := m.ctrl.Call(m, "GetSubnetByName", ctx, subnetName, region, vpc)
 	ret0, _ := ret[0].(*vpcv1.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSubnetByName indicates an expected call of GetSubnetByName.
-func (mr *MockAPIMockRecorder) GetSubnetByName(ctx, subnetName, region interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) GetSubnetByName(ctx, subnetName, region, vpc interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetByName", reflect.TypeOf((*MockAPI)(nil).GetSubnetByName), ctx, subnetName, region)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetByName", reflect.TypeOf((*MockAPI)(nil).GetSubnetByName), ctx, subnetName, region, vpc)
+}
+
+// GetSubnets mocks base method.
+func (m *MockAPI) GetSubnets(ctx context.Context, region string) ([]vpcv1.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnets", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnets indicates an expected call of GetSubnets.
+func (mr *MockAPIMockRecorder) GetSubnets(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnets", reflect.TypeOf((*MockAPI)(nil).GetSubnets), ctx, region)
 }
 
 // GetVPC mocks base method.
@@ -280,19 +370,34 @@ func (mr *MockAPIMockRecorder) GetVPC(ctx, vpcID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPC", reflect.TypeOf((*MockAPI)(nil).GetVPC), ctx, vpcID)
 }
 
+// GetVPCAddressPrefixes mocks base method.
+func (m *MockAPI) GetVPCAddressPrefixes(ctx context.Context, region, vpcID string) ([]vpcv1.AddressPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPCAddressPrefixes", ctx, region, vpcID)
+	ret0, _ := ret[0].([]vpcv1.AddressPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVPCAddressPrefixes indicates an expected call of GetVPCAddressPrefixes.
+func (mr *MockAPIMockRecorder) GetVPCAddressPrefixes(ctx, region, vpcID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCAddressPrefixes", reflect.TypeOf((*MockAPI)(nil).GetVPCAddressPrefixes), ctx, region, vpcID)
+}
+
 // GetVPCByName mocks base method.
-func (m *MockAPI) GetVPCByName(ctx context.Context, vpcName string) (*vpcv1.VPC, error) {
+func (m *MockAPI) GetVPCByName(ctx context.Context, vpcName, resourceGroup string) (*vpcv1.VPC, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVPCByName", ctx, vpcName)
+	ret := m.ctrl.Call(m, "GetVPCByName", ctx, vpcName, resourceGroup)
 	ret0, _ := ret[0].(*vpcv1.VPC)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVPCByName indicates an expected call of GetVPCByName.
-func (mr *MockAPIMockRecorder) GetVPCByName(ctx, vpcName interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) GetVPCByName(ctx, vpcName, resourceGroup interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCByName", reflect.TypeOf((*MockAPI)(nil).GetVPCByName), ctx, vpcName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCByName", reflect.TypeOf((*MockAPI)(nil).GetVPCByName), ctx, vpcName, resourceGroup)
 }
 
 // GetVPCZonesForRegion mocks base method.
@@ -326,18 +431,18 @@ func (mr *MockAPIMockRecorder) GetVPCs(ctx, region interface{}) *gomock.Call {
 }
 
 // GetVSIProfiles mocks base method.
-func (m *MockAPI) GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error) {
+func (m *MockAPI) GetVSIProfiles(ctx context.Context, region string) ([]vpcv1.InstanceProfile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVSIProfiles", ctx)
+	ret := m.ctrl.Call(m, "GetVSIProfiles", ctx, region)
 	ret0, _ := ret[0].([]vpcv1.InstanceProfile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVSIProfiles indicates an expected call of GetVSIProfiles.
-func (mr *MockAPIMockRecorder) GetVSIProfiles(ctx interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) GetVSIProfiles(ctx, region interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVSIProfiles", reflect.TypeOf((*MockAPI)(nil).GetVSIProfiles), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVSIProfiles", reflect.TypeOf((*MockAPI)(nil).GetVSIProfiles), ctx, region)
 }
 
 // SetVPCServiceURLForRegion mocks base method.
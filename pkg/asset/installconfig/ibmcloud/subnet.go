@@ -2,8 +2,12 @@ package ibmcloud
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
 // Subnet represents an IBM Cloud VPC Subnet
@@ -16,11 +20,14 @@ type Subnet struct {
 	Zone string
 }
 
-func getSubnets(ctx context.Context, client API, region string, subnetNames []string) (map[string]Subnet, error) {
+// getSubnets looks up the subnets by name in the VPC. Each subnet must be in
+// its own resource group, or the network resource group when it sets none.
+func getSubnets(ctx context.Context, client API, region string, vpc string, networkResourceGroup string, subnetConfigs []ibmcloud.Subnet) (map[string]Subnet, error) {
 	subnets := map[string]Subnet{}
 
-	for _, name := range subnetNames {
-		results, err := client.GetSubnetByName(ctx, name, region)
+	for _, subnetConfig := range subnetConfigs {
+		name := subnetConfig.Name
+		results, err := client.GetSubnetByName(ctx, name, region, vpc)
 		if err != nil {
 			return nil, errors.Wrapf(err, "getting subnet %s", name)
 		}
@@ -49,6 +56,19 @@ func getSubnets(ctx context.Context, client API, region string, subnetNames []st
 			return nil, errors.Errorf("%s has no Zone", *results.ID)
 		}
 
+		resourceGroup := subnetConfig.ResourceGroupName
+		if resourceGroup == "" {
+			resourceGroup = networkResourceGroup
+		}
+		if resourceGroup != "" {
+			if results.ResourceGroup == nil {
+				return nil, errors.Errorf("%s has no ResourceGroup", *results.ID)
+			}
+			if *results.ResourceGroup.ID != resourceGroup && *results.ResourceGroup.Name != resourceGroup {
+				return nil, errors.Errorf("%s is not in resource group %s", name, resourceGroup)
+			}
+		}
+
 		subnets[*results.ID] = Subnet{
 			CIDR: *results.Ipv4CIDRBlock,
 			CRN:  *results.CRN,
@@ -61,3 +81,29 @@ func getSubnets(ctx context.Context, client API, region string, subnetNames []st
 
 	return subnets, nil
 }
+
+// SubnetZones returns the sorted zones of the subnets.
+func SubnetZones(subnets map[string]Subnet) []string {
+	zones := sets.NewString()
+	for _, subnet := range subnets {
+		zones.Insert(subnet.Zone)
+	}
+	return zones.List()
+}
+
+// ValidateSubnetTopology checks that the subnets all belong to the named VPC
+// and that together they provide a subnet in each of the zones.
+func ValidateSubnetTopology(subnets map[string]Subnet, vpcName string, zones []string) error {
+	covered := sets.NewString()
+	for _, subnet := range subnets {
+		if subnet.VPC != vpcName {
+			return errors.Errorf("subnet %s is in VPC %s, expected VPC %s", subnet.Name, subnet.VPC, vpcName)
+		}
+		covered.Insert(subnet.Zone)
+	}
+
+	if missing := sets.NewString(zones...).Difference(covered); missing.Len() > 0 {
+		return errors.Errorf("no subnet provided for zones: %s", strings.Join(missing.List(), ", "))
+	}
+	return nil
+}
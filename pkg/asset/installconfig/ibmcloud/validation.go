@@ -4,15 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/responses"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/validate"
 )
 
+// maxDNSZonePermittedNetworks is the DNS Services limit of permitted networks
+// per zone.
+const maxDNSZonePermittedNetworks = 10
+
 // Validate executes platform-specific validation.
 func Validate(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
@@ -35,9 +43,57 @@ func Validate(client API, ic *types.InstallConfig) error {
 	return allErrs.ToAggregate()
 }
 
+// ValidateWithCatalog executes the platform-specific validation possible
+// without calling the IBM Cloud APIs, checking the zones and instance profiles
+// against the region catalog. The catalog may predate instance profiles, such
+// as the GPU (gx) profiles, so profiles not in it are only warned about.
+func ValidateWithCatalog(ic *types.InstallConfig) error {
+	allErrs := field.ErrorList{}
+	platformPath := field.NewPath("platform").Child("ibmcloud")
+
+	entry, ok := ibmcloud.CatalogRegion(ic.Platform.IBMCloud.Region)
+	if !ok {
+		return field.ErrorList{field.Invalid(platformPath.Child("region"), ic.Platform.IBMCloud.Region, "region is not in the region catalog")}.ToAggregate()
+	}
+	zones := sets.NewString(entry.Zones...)
+	profiles := sets.NewString(entry.Profiles...)
+
+	validateProfile := func(profile string, path *field.Path) {
+		if profile != "" && !profiles.Has(profile) {
+			logrus.Warnf("%s: instance profile %q is not in the region catalog of %s, it is only validated when IC_API_KEY is set", path, profile, ic.Platform.IBMCloud.Region)
+		}
+	}
+	validatePool := func(mpool *ibmcloud.MachinePool, path *field.Path) {
+		if mpool == nil {
+			return
+		}
+		validateProfile(mpool.InstanceType, path.Child("type"))
+		for idx, zone := range mpool.Zones {
+			if !zones.Has(zone) {
+				allErrs = append(allErrs, field.Invalid(path.Child("zones").Index(idx), zone, fmt.Sprintf("zone must be in region %q", ic.Platform.IBMCloud.Region)))
+			}
+		}
+	}
+
+	validatePool(ic.Platform.IBMCloud.DefaultMachinePlatform, platformPath.Child("defaultMachinePlatform"))
+	if ic.ControlPlane != nil {
+		validatePool(ic.ControlPlane.Platform.IBMCloud, field.NewPath("controlPlane").Child("platform").Child("ibmcloud"))
+	}
+	for idx, compute := range ic.Compute {
+		validatePool(compute.Platform.IBMCloud, field.NewPath("compute").Index(idx).Child("platform").Child("ibmcloud"))
+	}
+	validateProfile(ic.Platform.IBMCloud.BootstrapInstanceType, platformPath.Child("bootstrapInstanceType"))
+
+	return allErrs.ToAggregate()
+}
+
 func validatePlatform(client API, ic *types.InstallConfig, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if ic.Platform.IBMCloud.AccountID != "" {
+		allErrs = append(allErrs, validateAccountID(client, ic.Platform.IBMCloud.AccountID, path.Child("accountID"))...)
+	}
+
 	if ic.Platform.IBMCloud.ResourceGroupName != "" {
 		allErrs = append(allErrs, validateResourceGroup(client, ic.IBMCloud.ResourceGroupName, "resourceGroupName", path)...)
 	}
@@ -46,17 +102,42 @@ func validatePlatform(client API, ic *types.InstallConfig, path *field.Path) fie
 		allErrs = append(allErrs, validateExistingVPC(client, ic, path)...)
 	}
 
+	if ic.Publish == types.InternalPublishingStrategy && !ic.Platform.IBMCloud.IsUserProvisionedDNS() {
+		allErrs = append(allErrs, validateDNSServicesZone(client, ic, field.NewPath("baseDomain"))...)
+	}
+
+	if ic.Platform.IBMCloud.BootstrapInstanceType != "" {
+		allErrs = append(allErrs, validateMachinePoolType(client, ic.Platform.IBMCloud.Region, ic.Platform.IBMCloud.BootstrapInstanceType, path.Child("bootstrapInstanceType"))...)
+	}
+
 	if ic.Platform.IBMCloud.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, validateMachinePool(client, ic.IBMCloud, ic.Platform.IBMCloud.DefaultMachinePlatform, path)...)
 	}
 	return allErrs
 }
 
+func validateAccountID(client API, accountID string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	apiKeyDetails, err := client.GetAuthenticatorAPIKeyDetails(context.TODO())
+	if err != nil {
+		return append(allErrs, field.InternalError(path, err))
+	}
+	if apiKeyDetails.AccountID == nil || *apiKeyDetails.AccountID != accountID {
+		var keyAccountID string
+		if apiKeyDetails.AccountID != nil {
+			keyAccountID = *apiKeyDetails.AccountID
+		}
+		allErrs = append(allErrs, field.Invalid(path, accountID, fmt.Sprintf("API key belongs to account %s", keyAccountID)))
+	}
+	return allErrs
+}
+
 func validateMachinePool(client API, platform *ibmcloud.Platform, machinePool *ibmcloud.MachinePool, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if machinePool.InstanceType != "" {
-		allErrs = append(allErrs, validateMachinePoolType(client, machinePool.InstanceType, path.Child("type"))...)
+		allErrs = append(allErrs, validateMachinePoolType(client, platform.Region, machinePool.InstanceType, path.Child("type"))...)
 	}
 
 	if len(machinePool.Zones) > 0 {
@@ -146,8 +227,12 @@ func isDedicatedHostProfileInList(profile string, list []vpcv1.DedicatedHostProf
 	return false
 }
 
-func validateMachinePoolType(client API, machineType string, path *field.Path) field.ErrorList {
-	vsiProfiles, err := client.GetVSIProfiles(context.TODO())
+func validateMachinePoolType(client API, region string, machineType string, path *field.Path) field.ErrorList {
+	// Profiles, including the GPU (gx) profiles, are only listed in the
+	// regions that offer them. The machine pool MTU is not checked against the
+	// profile, since every VPC instance profile supports jumbo frames up to
+	// ibmcloud.MaxMTU and the API reports no MTU limit per profile.
+	vsiProfiles, err := client.GetVSIProfiles(context.TODO(), region)
 	if err != nil {
 		return field.ErrorList{field.InternalError(path, err)}
 	}
@@ -158,7 +243,7 @@ func validateMachinePoolType(client API, machineType string, path *field.Path) f
 		}
 	}
 
-	return field.ErrorList{field.NotFound(path, machineType)}
+	return field.ErrorList{field.Invalid(path, machineType, fmt.Sprintf("instance profile not available in region %s", region))}
 }
 
 func validateMachinePoolZones(client API, region string, zones []string, path *field.Path) field.ErrorList {
@@ -222,6 +307,52 @@ func validateResourceGroup(client API, resourceGroupName string, platformField s
 	return allErrs
 }
 
+// validateDNSServicesZone checks that the base domain is a DNS Services zone
+// and that the cluster VPC is, or can be added as, a permitted network of it.
+func validateDNSServicesZone(client API, ic *types.InstallConfig, path *field.Path) field.ErrorList {
+	zones, err := client.GetDNSZones(context.TODO(), types.InternalPublishingStrategy)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	var zone *responses.DNSZoneResponse
+	for i := range zones {
+		if zones[i].Name == ic.BaseDomain {
+			zone = &zones[i]
+			break
+		}
+	}
+	if zone == nil {
+		return field.ErrorList{field.NotFound(path, ic.BaseDomain)}
+	}
+
+	networks, err := client.GetDNSInstancePermittedNetworks(context.TODO(), zone.InstanceID, zone.ID)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	if ic.IBMCloud.VPCName != "" {
+		vpc, err := client.GetVPCByName(context.TODO(), ic.IBMCloud.VPCName, ic.IBMCloud.NetworkResourceGroupName)
+		if err != nil {
+			if errors.Is(err, &VPCResourceNotFoundError{}) {
+				// A missing VPC is reported by validateExistingVPC.
+				return nil
+			}
+			return field.ErrorList{field.InternalError(path, err)}
+		}
+		for _, network := range networks {
+			if network == *vpc.CRN {
+				return nil
+			}
+		}
+	}
+
+	if len(networks) >= maxDNSZonePermittedNetworks {
+		return field.ErrorList{field.Invalid(path, ic.BaseDomain, fmt.Sprintf("DNS zone already has the maximum of %d permitted networks, so the cluster VPC cannot be added", maxDNSZonePermittedNetworks))}
+	}
+	return nil
+}
+
 func validateExistingVPC(client API, ic *types.InstallConfig, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -233,6 +364,7 @@ func validateExistingVPC(client API, ic *types.InstallConfig, path *field.Path)
 		return append(allErrs, field.Invalid(path.Child("networkResourceGroupName"), ic.IBMCloud.NetworkResourceGroupName, fmt.Sprintf("networkResourceGroupName cannot be empty when providing a vpcName: %s", ic.IBMCloud.VPCName)))
 	}
 	allErrs = append(allErrs, validateResourceGroup(client, ic.IBMCloud.NetworkResourceGroupName, "networkResourceGroupName", path)...)
+	allErrs = append(allErrs, validateSubnetResourceGroups(client, ic, path)...)
 
 	vpcs, err := client.GetVPCs(context.TODO(), ic.IBMCloud.Region)
 	if err != nil {
@@ -247,6 +379,10 @@ func validateExistingVPC(client API, ic *types.InstallConfig, path *field.Path)
 			}
 			found = true
 			allErrs = append(allErrs, validateExistingSubnets(client, ic, path, *vpc.ID)...)
+			allErrs = append(allErrs, validateVPCAddressSpace(client, ic, path, *vpc.ID)...)
+			if ic.IBMCloud.SecurityGroups != nil {
+				allErrs = append(allErrs, validateExistingSecurityGroups(client, ic, path.Child("securityGroups"), *vpc.ID)...)
+			}
 			break
 		}
 	}
@@ -257,16 +393,50 @@ func validateExistingVPC(client API, ic *types.InstallConfig, path *field.Path)
 	return allErrs
 }
 
+// validateSubnetResourceGroups checks that the resource groups set on
+// individual subnets exist, looking each one up only once.
+func validateSubnetResourceGroups(client API, ic *types.InstallConfig, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	checked := sets.NewString(ic.IBMCloud.NetworkResourceGroupName)
+	for _, subnets := range []struct {
+		name    string
+		subnets []ibmcloud.Subnet
+	}{
+		{name: "controlPlaneSubnets", subnets: ic.IBMCloud.ControlPlaneSubnets},
+		{name: "computeSubnets", subnets: ic.IBMCloud.ComputeSubnets},
+	} {
+		for i, subnet := range subnets.subnets {
+			if checked.Has(subnet.ResourceGroupName) {
+				continue
+			}
+			checked.Insert(subnet.ResourceGroupName)
+			allErrs = append(allErrs, validateResourceGroup(client, subnet.ResourceGroupName, "resourceGroupName", path.Child(subnets.name).Index(i))...)
+		}
+	}
+	return allErrs
+}
+
+// subnetResourceGroup returns the resource group the subnet is expected in,
+// along with the field it comes from for error messages.
+func subnetResourceGroup(ic *types.InstallConfig, subnet ibmcloud.Subnet, subnetsField string, index int) (string, string) {
+	if subnet.ResourceGroupName != "" {
+		return subnet.ResourceGroupName, fmt.Sprintf("%s[%d].resourceGroupName", subnetsField, index)
+	}
+	return ic.IBMCloud.NetworkResourceGroupName, "networkResourceGroupName"
+}
+
 func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Path, vpcID string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	var regionalZones []string
 
 	if len(ic.IBMCloud.ControlPlaneSubnets) == 0 {
-		allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ic.IBMCloud.ControlPlaneSubnets, fmt.Sprintf("controlPlaneSubnets cannot be empty when providing a vpcName: %s", ic.IBMCloud.VPCName)))
+		allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ControlPlaneSubnets), fmt.Sprintf("controlPlaneSubnets cannot be empty when providing a vpcName: %s", ic.IBMCloud.VPCName)))
 	} else {
 		controlPlaneSubnetZones := make(map[string]int)
-		for _, controlPlaneSubnet := range ic.IBMCloud.ControlPlaneSubnets {
-			subnet, err := client.GetSubnetByName(context.TODO(), controlPlaneSubnet, ic.IBMCloud.Region)
+		for i, controlPlaneSubnetConfig := range ic.IBMCloud.ControlPlaneSubnets {
+			controlPlaneSubnet := controlPlaneSubnetConfig.Name
+			resourceGroup, resourceGroupField := subnetResourceGroup(ic, controlPlaneSubnetConfig, "controlPlaneSubnets", i)
+			subnet, err := client.GetSubnetByName(context.TODO(), controlPlaneSubnet, ic.IBMCloud.Region, vpcID)
 			if err != nil {
 				if errors.Is(err, &VPCResourceNotFoundError{}) {
 					allErrs = append(allErrs, field.NotFound(path.Child("controlPlaneSubnets"), controlPlaneSubnet))
@@ -277,8 +447,8 @@ func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Pa
 				if *subnet.VPC.ID != vpcID {
 					allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), controlPlaneSubnet, fmt.Sprintf("controlPlaneSubnets contains subnet: %s, not found in expected vpcID: %s", controlPlaneSubnet, vpcID)))
 				}
-				if *subnet.ResourceGroup.ID != ic.IBMCloud.NetworkResourceGroupName && *subnet.ResourceGroup.Name != ic.IBMCloud.NetworkResourceGroupName {
-					allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), controlPlaneSubnet, fmt.Sprintf("controlPlaneSubnets contains subnet: %s, not found in expected networkResourceGroupName: %s", controlPlaneSubnet, ic.IBMCloud.NetworkResourceGroupName)))
+				if *subnet.ResourceGroup.ID != resourceGroup && *subnet.ResourceGroup.Name != resourceGroup {
+					allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), controlPlaneSubnet, fmt.Sprintf("controlPlaneSubnets contains subnet: %s, not found in expected %s: %s", controlPlaneSubnet, resourceGroupField, resourceGroup)))
 				}
 				controlPlaneSubnetZones[*subnet.Zone.Name]++
 			}
@@ -298,22 +468,24 @@ func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Pa
 
 		// If lenght of found zones doesn't match actual or if an actual zone was not found from provided subnets, that is an invalid configuration
 		if len(controlPlaneSubnetZones) != len(controlPlaneActualZones) {
-			allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ic.IBMCloud.ControlPlaneSubnets, fmt.Sprintf("number of zones (%d) covered by controlPlaneSubnets does not match number of provided or default zones (%d) for control plane in %s", len(controlPlaneSubnetZones), len(controlPlaneActualZones), ic.IBMCloud.Region)))
+			allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ControlPlaneSubnets), fmt.Sprintf("number of zones (%d) covered by controlPlaneSubnets does not match number of provided or default zones (%d) for control plane in %s", len(controlPlaneSubnetZones), len(controlPlaneActualZones), ic.IBMCloud.Region)))
 		} else {
 			for _, actualZone := range controlPlaneActualZones {
 				if _, okay := controlPlaneSubnetZones[actualZone]; !okay {
-					allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ic.IBMCloud.ControlPlaneSubnets, fmt.Sprintf("%s zone does not have a provided control plane subnet", actualZone)))
+					allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ControlPlaneSubnets), fmt.Sprintf("%s zone does not have a provided control plane subnet", actualZone)))
 				}
 			}
 		}
 	}
 
 	if len(ic.IBMCloud.ComputeSubnets) == 0 {
-		allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ic.IBMCloud.ComputeSubnets, fmt.Sprintf("computeSubnets cannot be empty when providing a vpcName: %s", ic.IBMCloud.VPCName)))
+		allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ComputeSubnets), fmt.Sprintf("computeSubnets cannot be empty when providing a vpcName: %s", ic.IBMCloud.VPCName)))
 	} else {
 		computeSubnetZones := make(map[string]int)
-		for _, computeSubnet := range ic.IBMCloud.ComputeSubnets {
-			subnet, err := client.GetSubnetByName(context.TODO(), computeSubnet, ic.IBMCloud.Region)
+		for i, computeSubnetConfig := range ic.IBMCloud.ComputeSubnets {
+			computeSubnet := computeSubnetConfig.Name
+			resourceGroup, resourceGroupField := subnetResourceGroup(ic, computeSubnetConfig, "computeSubnets", i)
+			subnet, err := client.GetSubnetByName(context.TODO(), computeSubnet, ic.IBMCloud.Region, vpcID)
 			if err != nil {
 				if errors.Is(err, &VPCResourceNotFoundError{}) {
 					allErrs = append(allErrs, field.NotFound(path.Child("computeSubnets"), computeSubnet))
@@ -324,8 +496,8 @@ func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Pa
 				if *subnet.VPC.ID != vpcID {
 					allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), computeSubnet, fmt.Sprintf("computeSubnets contains subnet: %s, not found in expected vpcID: %s", computeSubnet, vpcID)))
 				}
-				if *subnet.ResourceGroup.ID != ic.IBMCloud.NetworkResourceGroupName && *subnet.ResourceGroup.Name != ic.IBMCloud.NetworkResourceGroupName {
-					allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), computeSubnet, fmt.Sprintf("computeSubnets contains subnet: %s, not found in expected networkResourceGroupName: %s", computeSubnet, ic.IBMCloud.NetworkResourceGroupName)))
+				if *subnet.ResourceGroup.ID != resourceGroup && *subnet.ResourceGroup.Name != resourceGroup {
+					allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), computeSubnet, fmt.Sprintf("computeSubnets contains subnet: %s, not found in expected %s: %s", computeSubnet, resourceGroupField, resourceGroup)))
 				}
 				computeSubnetZones[*subnet.Zone.Name]++
 			}
@@ -348,11 +520,11 @@ func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Pa
 
 			// If length of found zones doesn't match actual or if an actual zone was not found from provided subnets, that is an invalid configuration
 			if len(computeSubnetZones) != len(computeActualZones) {
-				allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ic.IBMCloud.ComputeSubnets, fmt.Sprintf("number of zones (%d) covered by computeSubnets does not match number of provided or default zones (%d) for compute[%d] in %s", len(computeSubnetZones), len(computeActualZones), index, ic.IBMCloud.Region)))
+				allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ComputeSubnets), fmt.Sprintf("number of zones (%d) covered by computeSubnets does not match number of provided or default zones (%d) for compute[%d] in %s", len(computeSubnetZones), len(computeActualZones), index, ic.IBMCloud.Region)))
 			} else {
 				for _, actualZone := range computeActualZones {
 					if _, okay := computeSubnetZones[actualZone]; !okay {
-						allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ic.IBMCloud.ComputeSubnets, fmt.Sprintf("%s zone does not have a provided compute subnet", actualZone)))
+						allErrs = append(allErrs, field.Invalid(path.Child("computeSubnets"), ibmcloud.SubnetNames(ic.IBMCloud.ComputeSubnets), fmt.Sprintf("%s zone does not have a provided compute subnet", actualZone)))
 					}
 				}
 			}
@@ -362,6 +534,34 @@ func validateExistingSubnets(client API, ic *types.InstallConfig, path *field.Pa
 	return allErrs
 }
 
+func validateExistingSecurityGroups(client API, ic *types.InstallConfig, path *field.Path, vpcID string) field.ErrorList {
+	securityGroups, err := client.GetSecurityGroups(context.TODO(), ic.IBMCloud.Region, vpcID)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	existing := sets.NewString()
+	for _, securityGroup := range securityGroups {
+		existing.Insert(*securityGroup.Name)
+	}
+
+	allErrs := field.ErrorList{}
+	for _, groups := range []struct {
+		name  string
+		names []string
+	}{
+		{name: "controlPlane", names: ic.IBMCloud.SecurityGroups.ControlPlane},
+		{name: "compute", names: ic.IBMCloud.SecurityGroups.Compute},
+		{name: "loadBalancer", names: ic.IBMCloud.SecurityGroups.LoadBalancer},
+	} {
+		for i, name := range groups.names {
+			if !existing.Has(name) {
+				allErrs = append(allErrs, field.NotFound(path.Child(groups.name).Index(i), name))
+			}
+		}
+	}
+	return allErrs
+}
+
 func validateSubnetZone(client API, subnetID string, validZones sets.String, subnetPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if subnet, err := client.GetSubnet(context.TODO(), subnetID); err == nil {
@@ -379,6 +579,67 @@ func validateSubnetZone(client API, subnetID string, validZones sets.String, sub
 	return allErrs
 }
 
+// validateVPCAddressSpace checks the machine networks against the address
+// prefixes and subnets of an existing VPC. Nodes are only addressed from the
+// cluster subnets, so conflicts are warned about with the steps to resolve
+// them rather than failing configs that install today.
+func validateVPCAddressSpace(client API, ic *types.InstallConfig, path *field.Path, vpcID string) field.ErrorList {
+	if ic.Networking == nil || len(ic.Networking.MachineNetwork) == 0 {
+		return nil
+	}
+
+	addressPrefixes, err := client.GetVPCAddressPrefixes(context.TODO(), ic.IBMCloud.Region, vpcID)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path.Child("vpcName"), err)}
+	}
+	subnets, err := client.GetSubnets(context.TODO(), ic.IBMCloud.Region)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path.Child("vpcName"), err)}
+	}
+
+	prefixCIDRs := []*net.IPNet{}
+	for _, addressPrefix := range addressPrefixes {
+		if _, cidr, err := net.ParseCIDR(*addressPrefix.CIDR); err == nil {
+			prefixCIDRs = append(prefixCIDRs, cidr)
+		}
+	}
+	clusterSubnets := sets.NewString()
+	for _, subnet := range ic.IBMCloud.ControlPlaneSubnets {
+		clusterSubnets.Insert(subnet.Name)
+	}
+	for _, subnet := range ic.IBMCloud.ComputeSubnets {
+		clusterSubnets.Insert(subnet.Name)
+	}
+
+	for _, network := range ic.Networking.MachineNetwork {
+		machineCIDR := &network.CIDR.IPNet
+		inPrefix := false
+		for _, prefixCIDR := range prefixCIDRs {
+			if validate.DoCIDRsOverlap(prefixCIDR, machineCIDR) {
+				inPrefix = true
+				break
+			}
+		}
+		if !inPrefix {
+			logrus.Warnf("machineNetwork %s does not overlap any address prefix of VPC %s %v, set networking.machineNetwork to the address prefixes of the cluster subnets", machineCIDR, ic.IBMCloud.VPCName, prefixCIDRs)
+		}
+
+		for _, subnet := range subnets {
+			if subnet.VPC == nil || *subnet.VPC.ID != vpcID || subnet.Ipv4CIDRBlock == nil || clusterSubnets.Has(*subnet.Name) {
+				continue
+			}
+			_, subnetCIDR, err := net.ParseCIDR(*subnet.Ipv4CIDRBlock)
+			if err != nil {
+				continue
+			}
+			if validate.DoCIDRsOverlap(subnetCIDR, machineCIDR) {
+				logrus.Warnf("machineNetwork %s overlaps subnet %s (%s) of VPC %s that is not a cluster subnet, narrow networking.machineNetwork to the cluster subnets so addresses of other workloads are not treated as cluster machines", machineCIDR, *subnet.Name, subnetCIDR, ic.IBMCloud.VPCName)
+			}
+		}
+	}
+	return nil
+}
+
 // ValidatePreExistingPublicDNS ensure no pre-existing DNS record exists in the CIS
 // DNS zone for cluster's Kubernetes API.
 func ValidatePreExistingPublicDNS(client API, ic *types.InstallConfig, metadata *Metadata) error {
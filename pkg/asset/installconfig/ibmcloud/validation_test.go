@@ -7,13 +7,17 @@ import (
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/platform-services-go-sdk/iamidentityv1"
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/golang/mock/gomock"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/responses"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
@@ -22,6 +26,7 @@ import (
 type editFunctions []func(ic *types.InstallConfig)
 
 var (
+	validAccountID               = "valid-account-id"
 	validRegion                  = "us-south"
 	validCIDR                    = "10.0.0.0/16"
 	validCISInstanceCRN          = "crn:v1:bluemix:public:internet-svcs:global:a/valid-account-id:valid-instance-id::"
@@ -116,7 +121,8 @@ var (
 		},
 	}
 	validSubnet1 = &vpcv1.Subnet{
-		Name: &validSubnet1Name,
+		Name:          &validSubnet1Name,
+		Ipv4CIDRBlock: &[]string{"10.0.0.0/24"}[0],
 		VPC: &vpcv1.VPCReference{
 			Name: &validVPC,
 			ID:   &validVPCID,
@@ -130,7 +136,8 @@ var (
 		},
 	}
 	validSubnet2 = &vpcv1.Subnet{
-		Name: &validSubnet2Name,
+		Name:          &validSubnet2Name,
+		Ipv4CIDRBlock: &[]string{"10.0.1.0/24"}[0],
 		VPC: &vpcv1.VPCReference{
 			Name: &validVPC,
 			ID:   &validVPCID,
@@ -144,7 +151,8 @@ var (
 		},
 	}
 	validSubnet3 = &vpcv1.Subnet{
-		Name: &validSubnet3Name,
+		Name:          &validSubnet3Name,
+		Ipv4CIDRBlock: &[]string{"10.0.2.0/24"}[0],
 		VPC: &vpcv1.VPCReference{
 			Name: &validVPC,
 			ID:   &validVPCID,
@@ -172,7 +180,9 @@ var (
 		},
 	}
 
-	validInstanceProfies = []vpcv1.InstanceProfile{{Name: &[]string{"type-a"}[0]}, {Name: &[]string{"type-b"}[0]}}
+	validAddressPrefixes = []vpcv1.AddressPrefix{{CIDR: core.StringPtr(validCIDR)}}
+
+	validInstanceProfies = []vpcv1.InstanceProfile{{Name: &[]string{"type-a"}[0]}, {Name: &[]string{"type-b"}[0]}, {Name: &[]string{"gx2-8x64x1v100"}[0]}}
 
 	machinePoolInvalidType = func(ic *types.InstallConfig) {
 		ic.ControlPlane.Platform.IBMCloud = &ibmcloudtypes.MachinePool{
@@ -180,6 +190,20 @@ var (
 		}
 	}
 
+	validDNSServicesInstanceID = "valid-dns-instance-id"
+	validDNSServicesZones      = []responses.DNSZoneResponse{{
+		Name:       validBaseDomain,
+		ID:         validDNSZoneID,
+		InstanceID: validDNSServicesInstanceID,
+	}}
+	fullPermittedNetworks = func() []string {
+		networks := make([]string, 10)
+		for i := range networks {
+			networks[i] = fmt.Sprintf("crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-%d", i)
+		}
+		return networks
+	}()
+
 	existingDNSRecordsResponse = []dnsrecordsv1.DnsrecordDetails{
 		{
 			ID: core.StringPtr("valid-dns-record-1"),
@@ -247,7 +271,7 @@ func validControlPlaneSubnetsForZones(ic *types.InstallConfig, zones []string) {
 		zones = validZones
 	}
 	for _, zone := range zones {
-		ic.Platform.IBMCloud.ControlPlaneSubnets = append(ic.Platform.IBMCloud.ControlPlaneSubnets, validZoneSubnetNameMap[zone])
+		ic.Platform.IBMCloud.ControlPlaneSubnets = append(ic.Platform.IBMCloud.ControlPlaneSubnets, ibmcloudtypes.Subnet{Name: validZoneSubnetNameMap[zone]})
 	}
 }
 
@@ -257,7 +281,7 @@ func validComputeSubnetsForZones(ic *types.InstallConfig, zones []string) {
 		zones = validZones
 	}
 	for _, zone := range zones {
-		ic.Platform.IBMCloud.ComputeSubnets = append(ic.Platform.IBMCloud.ComputeSubnets, validZoneSubnetNameMap[zone])
+		ic.Platform.IBMCloud.ComputeSubnets = append(ic.Platform.IBMCloud.ComputeSubnets, ibmcloudtypes.Subnet{Name: validZoneSubnetNameMap[zone]})
 	}
 }
 
@@ -313,7 +337,7 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{"missing-cp-subnet"}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: "missing-cp-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.controlPlaneSubnets: Not found: "missing-cp-subnet"`,
@@ -324,7 +348,7 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{"ibm-error-cp-subnet"}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: "ibm-error-cp-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.controlPlaneSubnets: Internal error: ibmcloud error`,
@@ -337,7 +361,7 @@ func TestValidate(t *testing.T) {
 					ic.Platform.IBMCloud.VPCName = "wrong-vpc"
 				},
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{"valid-subnet"}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: "valid-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.controlPlaneSubnets: Invalid value: "valid-subnet": controlPlaneSubnets contains subnet: valid-subnet, not found in expected vpcID: wrong-id`,
@@ -350,7 +374,7 @@ func TestValidate(t *testing.T) {
 				},
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{"valid-subnet"}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: "valid-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.controlPlaneSubnets: Invalid value: "valid-subnet": controlPlaneSubnets contains subnet: valid-subnet, not found in expected networkResourceGroupName: wrong-resource-group`,
@@ -361,8 +385,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 			},
 		},
@@ -372,8 +396,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.ControlPlane.Platform.IBMCloud = nil
@@ -386,8 +410,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.ControlPlane.Platform.IBMCloud.Zones = validZones
@@ -401,8 +425,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.ControlPlane.Platform.IBMCloud.Zones = []string{"us-south-2", "us-south-3"}
@@ -415,8 +439,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.ControlPlane.Platform.IBMCloud.Zones = validZones
@@ -437,7 +461,7 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ComputeSubnets = []string{"missing-compute-subnet"}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: "missing-compute-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.computeSubnets: Not found: "missing-compute-subnet"`,
@@ -448,7 +472,7 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ComputeSubnets = []string{"ibm-error-compute-subnet"}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: "ibm-error-compute-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.computeSubnets: Internal error: ibmcloud error`,
@@ -461,7 +485,7 @@ func TestValidate(t *testing.T) {
 					ic.Platform.IBMCloud.VPCName = "wrong-vpc"
 				},
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ComputeSubnets = []string{"valid-subnet"}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: "valid-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.computeSubnets: Invalid value: "valid-subnet": computeSubnets contains subnet: valid-subnet, not found in expected vpcID: wrong-id`,
@@ -474,7 +498,7 @@ func TestValidate(t *testing.T) {
 				},
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ComputeSubnets = []string{"valid-subnet"}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: "valid-subnet"}}
 				},
 			},
 			errorMsg: `platform.ibmcloud.computeSubnets: Invalid value: "valid-subnet": computeSubnets contains subnet: valid-subnet, not found in expected networkResourceGroupName: wrong-resource-group`,
@@ -485,8 +509,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 			},
 		},
@@ -496,8 +520,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.Compute[0].Platform.IBMCloud = nil
@@ -510,8 +534,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.Compute[0].Platform.IBMCloud.Zones = validZones
@@ -525,8 +549,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet2Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.Compute[0].Platform.IBMCloud.Zones = []string{validZoneUSSouth2}
@@ -539,8 +563,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					secondCompute := types.MachinePool{
@@ -561,8 +585,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					secondCompute := types.MachinePool{
@@ -582,8 +606,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					ic.Compute[0].Platform.IBMCloud.Zones = validZones
@@ -596,8 +620,8 @@ func TestValidate(t *testing.T) {
 				validNetworkResourceGroupName,
 				validVPCName,
 				func(ic *types.InstallConfig) {
-					ic.Platform.IBMCloud.ControlPlaneSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
-					ic.Platform.IBMCloud.ComputeSubnets = []string{validSubnet1Name, validSubnet2Name, validSubnet3Name}
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
 				},
 				func(ic *types.InstallConfig) {
 					secondCompute := types.MachinePool{
@@ -611,6 +635,130 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GPU machine type",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Compute[0].Platform.IBMCloud.InstanceType = "gx2-8x64x1v100"
+				},
+			},
+		},
+		{
+			name: "machine type not in region",
+			edits: editFunctions{
+				machinePoolInvalidType,
+			},
+			errorMsg: `controlPlane.platform.ibmcloud.type: Invalid value: "invalid-type": instance profile not available in region us-south`,
+		},
+		{
+			name: "existing security groups",
+			edits: editFunctions{
+				validNetworkResourceGroupName,
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.SecurityGroups = &ibmcloudtypes.SecurityGroups{
+						ControlPlane: []string{"sg-cp"},
+						Compute:      []string{"sg-compute", "sg-missing"},
+						LoadBalancer: []string{"sg-lb-missing"},
+					}
+				},
+			},
+			errorMsg: `platform.ibmcloud.securityGroups.compute\[1\]: Not found: "sg-missing", platform.ibmcloud.securityGroups.loadBalancer\[0\]: Not found: "sg-lb-missing"`,
+		},
+		{
+			name: "bootstrap instance type",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.BootstrapInstanceType = "type-b"
+				},
+			},
+		},
+		{
+			name: "bootstrap instance type not in region",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.BootstrapInstanceType = "invalid-type"
+				},
+			},
+			errorMsg: `platform\.ibmcloud\.bootstrapInstanceType: Invalid value: "invalid-type": instance profile not available in region us-south`,
+		},
+		{
+			name: "account ID matches API key",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.AccountID = validAccountID
+				},
+			},
+		},
+		{
+			name: "account ID does not match API key",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.AccountID = "other-account-id"
+				},
+			},
+			errorMsg: `platform.ibmcloud.accountID: Invalid value: "other-account-id": API key belongs to account valid-account-id`,
+		},
+		{
+			name: "internal DNS zone",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Publish = types.InternalPublishingStrategy
+				},
+			},
+		},
+		{
+			name: "internal DNS zone not found",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Publish = types.InternalPublishingStrategy
+				},
+			},
+			errorMsg: `baseDomain: Not found: "valid.base.domain"`,
+		},
+		{
+			name: "internal DNS zone permitted networks full",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Publish = types.InternalPublishingStrategy
+				},
+			},
+			errorMsg: `baseDomain: Invalid value: "valid.base.domain": DNS zone already has the maximum of 10 permitted networks, so the cluster VPC cannot be added`,
+		},
+		{
+			name: "internal DNS zone VPC already permitted",
+			edits: editFunctions{
+				validNetworkResourceGroupName,
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Publish = types.InternalPublishingStrategy
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}, {Name: validSubnet2Name}, {Name: validSubnet3Name}}
+				},
+			},
+		},
+		{
+			name: "internal DNS zone with user-provisioned DNS",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Publish = types.InternalPublishingStrategy
+					ic.Platform.IBMCloud.UserProvisionedDNS = ibmcloudtypes.UserProvisionedDNSEnabled
+				},
+			},
+		},
+		{
+			name: "control plane subnet not in its ResourceGroup",
+			edits: editFunctions{
+				validNetworkResourceGroupName,
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: "valid-subnet", ResourceGroupName: anotherValidRG}}
+				},
+			},
+			errorMsg: `platform.ibmcloud.controlPlaneSubnets: Invalid value: "valid-subnet": controlPlaneSubnets contains subnet: valid-subnet, not found in expected controlPlaneSubnets\[0\].resourceGroupName: another-valid-resource-group`,
+		},
 	}
 
 	mockCtrl := gomock.NewController(t)
@@ -624,8 +772,10 @@ func TestValidate(t *testing.T) {
 	ibmcloudClient.EXPECT().GetSubnet(gomock.Any(), validPrivateSubnetUSSouth1ID).Return(&vpcv1.Subnet{Zone: &vpcv1.ZoneReference{Name: &validZoneUSSouth1}}, nil).AnyTimes()
 	ibmcloudClient.EXPECT().GetSubnet(gomock.Any(), validPrivateSubnetUSSouth2ID).Return(&vpcv1.Subnet{Zone: &vpcv1.ZoneReference{Name: &validZoneUSSouth1}}, nil).AnyTimes()
 	ibmcloudClient.EXPECT().GetSubnet(gomock.Any(), "subnet-invalid-zone").Return(&vpcv1.Subnet{Zone: &vpcv1.ZoneReference{Name: &[]string{"invalid"}[0]}}, nil).AnyTimes()
-	ibmcloudClient.EXPECT().GetVSIProfiles(gomock.Any()).Return(validInstanceProfies, nil).AnyTimes()
+	ibmcloudClient.EXPECT().GetVSIProfiles(gomock.Any(), validRegion).Return(validInstanceProfies, nil).AnyTimes()
 	ibmcloudClient.EXPECT().GetVPCZonesForRegion(gomock.Any(), validRegion).Return([]string{"us-south-1", "us-south-2", "us-south-3"}, nil).AnyTimes()
+	ibmcloudClient.EXPECT().GetVPCAddressPrefixes(gomock.Any(), validRegion, gomock.Any()).Return(validAddressPrefixes, nil).AnyTimes()
+	ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), validRegion).Return([]vpcv1.Subnet{*validSubnet1, *validSubnet2, *validSubnet3}, nil).AnyTimes()
 
 	// Mocks: VPC with no ResourceGroup supplied
 	// No mocks required
@@ -645,57 +795,57 @@ func TestValidate(t *testing.T) {
 	// Mocks: control plane subnet not found
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "missing-cp-subnet", validRegion).Return(nil, &VPCResourceNotFoundError{})
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "missing-cp-subnet", validRegion, validVPCID).Return(nil, &VPCResourceNotFoundError{})
 
 	// Mocks: control plane subnet IBM error
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "ibm-error-cp-subnet", validRegion).Return(nil, errors.New("ibmcloud error"))
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "ibm-error-cp-subnet", validRegion, validVPCID).Return(nil, errors.New("ibmcloud error"))
 
 	// Mocks: control plane subnet invalid VPC
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(invalidVPC, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion, wrongVPCID).Return(validSubnet1, nil)
 
 	// Mocks: control plane subnet invalid ResourceGroup
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCInvalidRG, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion, validVPCID).Return(validSubnet1, nil)
 
 	// Mocks: control plane subnet no zones
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: control plane subnet no machinepoolplatform
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: control plane subnet invalid zones
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil)
 
 	// Mocks: control plane subnet valid zones some
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: control plane subnet valid zones all
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: VPC with no compute subnets
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
@@ -704,78 +854,121 @@ func TestValidate(t *testing.T) {
 	// Mocks: compute subnet not found
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "missing-compute-subnet", validRegion).Return(nil, &VPCResourceNotFoundError{})
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "missing-compute-subnet", validRegion, validVPCID).Return(nil, &VPCResourceNotFoundError{})
 
 	// Mocks: compute subnet IBM error
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "ibm-error-compute-subnet", validRegion).Return(nil, errors.New("ibmcloud error"))
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "ibm-error-compute-subnet", validRegion, validVPCID).Return(nil, errors.New("ibmcloud error"))
 
 	// Mocks: compute subnet invalid VPC
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(invalidVPC, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion, wrongVPCID).Return(validSubnet1, nil)
 
 	// Mocks: compute subnet invalid ResourceGroup
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCInvalidRG, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion, validVPCID).Return(validSubnet1, nil)
 
 	// Mocks: compute subnet no zones
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: compute subnet no machinepoolplatform
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: compute subnet invalid zones
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil)
 
 	// Mocks: single compute subnet valid zones some
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil)
 
 	// Mocks: multiple compute subnet invalid zones some
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: multiple compute subnet valid zones some
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: single compute subnet valid zones all
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
 
 	// Mocks: multiple compute subnet valid zones all
 	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
 	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion).Return(validSubnet1, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion).Return(validSubnet2, nil).Times(2)
-	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
+
+	// Mocks: existing security groups
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSecurityGroups(gomock.Any(), validRegion, validVPCID).Return([]vpcv1.SecurityGroup{
+		{Name: core.StringPtr("sg-cp")},
+		{Name: core.StringPtr("sg-compute")},
+	}, nil)
+
+	// Mocks: account ID matches API key
+	ibmcloudClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(&iamidentityv1.APIKey{AccountID: &validAccountID}, nil)
+
+	// Mocks: account ID does not match API key
+	ibmcloudClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(&iamidentityv1.APIKey{AccountID: &validAccountID}, nil)
+
+	// Internal DNS zone
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return(validDNSServicesZones, nil)
+	ibmcloudClient.EXPECT().GetDNSInstancePermittedNetworks(gomock.Any(), validDNSServicesInstanceID, validDNSZoneID).Return([]string{}, nil)
+
+	// Internal DNS zone not found
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return([]responses.DNSZoneResponse{}, nil)
+
+	// Internal DNS zone permitted networks full
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return(validDNSServicesZones, nil)
+	ibmcloudClient.EXPECT().GetDNSInstancePermittedNetworks(gomock.Any(), validDNSServicesInstanceID, validDNSZoneID).Return(fullPermittedNetworks, nil)
+
+	// Internal DNS zone VPC already permitted
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil)
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet1Name, validRegion, validVPCID).Return(validSubnet1, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet2Name, validRegion, validVPCID).Return(validSubnet2, nil).Times(2)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), validSubnet3Name, validRegion, validVPCID).Return(validSubnet3, nil).Times(2)
+	ibmcloudClient.EXPECT().GetDNSZones(gomock.Any(), types.InternalPublishingStrategy).Return(validDNSServicesZones, nil)
+	ibmcloudClient.EXPECT().GetDNSInstancePermittedNetworks(gomock.Any(), validDNSServicesInstanceID, validDNSZoneID).Return(fullPermittedNetworks, nil)
+	ibmcloudClient.EXPECT().GetVPCByName(gomock.Any(), validVPC, validRG).Return(&vpcv1.VPC{CRN: &fullPermittedNetworks[0]}, nil)
+
+	// Control plane subnet not in its ResourceGroup
+	ibmcloudClient.EXPECT().GetResourceGroups(gomock.Any()).Return(validResourceGroups, nil).Times(2)
+	ibmcloudClient.EXPECT().GetVPCs(gomock.Any(), validRegion).Return(validVPCs, nil)
+	ibmcloudClient.EXPECT().GetSubnetByName(gomock.Any(), "valid-subnet", validRegion, validVPCID).Return(validSubnet1, nil)
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -794,6 +987,88 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateWithCatalog(t *testing.T) {
+	cases := []struct {
+		name        string
+		edits       editFunctions
+		errorMsg    string
+		expectedLog string
+	}{
+		{
+			name: "valid install config",
+		},
+		{
+			name: "catalog zones and profile",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.Zones = []string{validZoneUSSouth1, validZoneUSSouth2}
+					ic.Compute[0].Platform.IBMCloud.InstanceType = "bx2-4x16"
+				},
+			},
+		},
+		{
+			name: "region not in catalog",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.Region = "us-west"
+				},
+			},
+			errorMsg: `^platform\.ibmcloud\.region: Invalid value: "us-west": region is not in the region catalog$`,
+		},
+		{
+			name: "zone not in catalog",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.ControlPlane.Platform.IBMCloud.Zones = []string{"us-south-4"}
+				},
+			},
+			errorMsg: `^controlPlane\.platform\.ibmcloud\.zones\[0\]: Invalid value: "us-south-4": zone must be in region "us-south"$`,
+		},
+		{
+			name: "profile not in catalog",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Compute[0].Platform.IBMCloud.InstanceType = "invalid-type"
+				},
+			},
+			expectedLog: `^compute\[0\]\.platform\.ibmcloud\.type: instance profile "invalid-type" is not in the region catalog of us-south, it is only validated when IC_API_KEY is set$`,
+		},
+		{
+			name: "gpu profile not in catalog",
+			edits: editFunctions{
+				func(ic *types.InstallConfig) {
+					ic.Compute[0].Platform.IBMCloud.InstanceType = "gx2-8x64x1v100"
+				},
+			},
+			expectedLog: `^compute\[0\]\.platform\.ibmcloud\.type: instance profile "gx2-8x64x1v100" is not in the region catalog of us-south, it is only validated when IC_API_KEY is set$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			editedInstallConfig := validInstallConfig()
+			for _, edit := range tc.edits {
+				edit(editedInstallConfig)
+			}
+
+			hook := logrusTest.NewGlobal()
+			aggregatedErrors := ValidateWithCatalog(editedInstallConfig)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
+			} else {
+				assert.NoError(t, aggregatedErrors)
+			}
+			if tc.expectedLog != "" {
+				if assert.NotNil(t, hook.LastEntry()) {
+					assert.Regexp(t, tc.expectedLog, hook.LastEntry().Message)
+				}
+			} else {
+				assert.Empty(t, hook.AllEntries())
+			}
+		})
+	}
+}
+
 func TestValidatePreExistingPublicDNS(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -835,7 +1110,7 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 
 	dnsRecordName := fmt.Sprintf("api.%s.%s", validClusterName, validBaseDomain)
 
-	metadata := NewMetadata(validBaseDomain, "us-south", nil, nil)
+	metadata := NewMetadata(validInstallConfig())
 	metadata.cisInstanceCRN = validCISInstanceCRN
 
 	// Mocks: no pre-existing External DNS records
@@ -868,3 +1143,111 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateVPCAddressSpace(t *testing.T) {
+	otherSubnetName := "other-subnet"
+	otherSubnet := vpcv1.Subnet{
+		Name:          &otherSubnetName,
+		Ipv4CIDRBlock: core.StringPtr("10.0.128.0/24"),
+		VPC: &vpcv1.VPCReference{
+			Name: &validVPC,
+			ID:   &validVPCID,
+		},
+	}
+
+	cases := []struct {
+		name        string
+		edits       editFunctions
+		subnets     []vpcv1.Subnet
+		errorMsg    string
+		expectedLog string
+	}{
+		{
+			name: "machine network in VPC address prefixes",
+			edits: editFunctions{
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}}
+				},
+			},
+			subnets: []vpcv1.Subnet{*validSubnet1, *validSubnet2},
+		},
+		{
+			name: "machine network outside VPC address prefixes",
+			edits: editFunctions{
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}}
+					ic.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("192.168.0.0/16")}}
+				},
+			},
+			subnets:     []vpcv1.Subnet{*validSubnet1, *validSubnet2},
+			expectedLog: `^machineNetwork 192\.168\.0\.0/16 does not overlap any address prefix of VPC valid-vpc \[10\.0\.0\.0/16\]`,
+		},
+		{
+			name: "machine network overlaps other subnet",
+			edits: editFunctions{
+				validVPCName,
+				func(ic *types.InstallConfig) {
+					ic.Platform.IBMCloud.ControlPlaneSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet1Name}}
+					ic.Platform.IBMCloud.ComputeSubnets = []ibmcloudtypes.Subnet{{Name: validSubnet2Name}}
+				},
+			},
+			subnets:     []vpcv1.Subnet{*validSubnet1, *validSubnet2, otherSubnet},
+			expectedLog: `^machineNetwork 10\.0\.0\.0/16 overlaps subnet other-subnet \(10\.0\.128\.0/24\) of VPC valid-vpc that is not a cluster subnet`,
+		},
+		{
+			name: "address prefixes IBM error",
+			edits: editFunctions{
+				validVPCName,
+			},
+			errorMsg: `^platform\.ibmcloud\.vpcName: Internal error: ibmcloud error$`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ibmcloudClient := mock.NewMockAPI(mockCtrl)
+
+	// Mocks: machine network in VPC address prefixes
+	ibmcloudClient.EXPECT().GetVPCAddressPrefixes(gomock.Any(), validRegion, validVPCID).Return(validAddressPrefixes, nil)
+
+	// Mocks: machine network outside VPC address prefixes
+	ibmcloudClient.EXPECT().GetVPCAddressPrefixes(gomock.Any(), validRegion, validVPCID).Return(validAddressPrefixes, nil)
+
+	// Mocks: machine network overlaps other subnet
+	ibmcloudClient.EXPECT().GetVPCAddressPrefixes(gomock.Any(), validRegion, validVPCID).Return(validAddressPrefixes, nil)
+
+	// Mocks: address prefixes IBM error
+	ibmcloudClient.EXPECT().GetVPCAddressPrefixes(gomock.Any(), validRegion, validVPCID).Return(nil, errors.New("ibmcloud error"))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			editedInstallConfig := validInstallConfig()
+			for _, edit := range tc.edits {
+				edit(editedInstallConfig)
+			}
+			if tc.subnets != nil {
+				ibmcloudClient.EXPECT().GetSubnets(gomock.Any(), validRegion).Return(tc.subnets, nil)
+			}
+
+			hook := logrusTest.NewGlobal()
+			errs := validateVPCAddressSpace(ibmcloudClient, editedInstallConfig, field.NewPath("platform").Child("ibmcloud"), validVPCID)
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, errs.ToAggregate())
+			} else {
+				assert.Empty(t, errs)
+			}
+			if tc.expectedLog != "" {
+				if assert.NotNil(t, hook.LastEntry()) {
+					assert.Regexp(t, tc.expectedLog, hook.LastEntry().Message)
+				}
+			} else {
+				assert.Empty(t, hook.AllEntries())
+			}
+		})
+	}
+}
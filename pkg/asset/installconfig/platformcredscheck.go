@@ -75,7 +75,7 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "validating credentials")
 		}
 	case ibmcloud.Name:
-		_, err = ibmcloudconfig.NewClient()
+		_, err = ibmcloudconfig.NewClient(ic.Config.Platform.IBMCloud.ServiceEndpoints)
 		if err != nil {
 			return errors.Wrap(err, "creating IBM Cloud session")
 		}
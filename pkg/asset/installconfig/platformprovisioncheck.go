@@ -123,7 +123,10 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 	case ibmcloud.Name:
-		client, err := ibmcloudconfig.NewClient()
+		if ic.Config.Platform.IBMCloud.IsUserProvisionedDNS() {
+			break
+		}
+		client, err := ic.IBMCloud.Client()
 		if err != nil {
 			return err
 		}
@@ -11,6 +11,7 @@ import (
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
 	ibmcloudprovider "github.com/openshift/machine-api-provider-ibmcloud/pkg/apis/ibmcloudprovider/v1"
 )
 
@@ -31,9 +32,19 @@ func Machines(clusterID string, config *types.InstallConfig, subnets map[string]
 		total = *pool.Replicas
 	}
 
+	if len(subnets) == 0 {
+		// Generate the names of the installer-created subnets once for the
+		// whole pool.
+		var err error
+		subnets, err = names.SubnetNames(clusterID, names.Role(role), azs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var machines []machineapi.Machine
 	for idx := int64(0); idx < total; idx++ {
-		azIndex := int(idx) % len(azs)
+		azIndex := machineZoneIndex(idx, len(azs))
 		provider, err := provider(clusterID, platform, subnets, mpool, azIndex, role, userDataSecret)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
@@ -45,12 +56,13 @@ func Machines(clusterID string, config *types.InstallConfig, subnets map[string]
 			},
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: "openshift-machine-api",
-				Name:      fmt.Sprintf("%s-%s-%d", clusterID, pool.Name, idx),
-				Labels: map[string]string{
+				Name:      names.MachineName(clusterID, pool.Name, idx),
+				Labels: machineLabels(mpool, map[string]string{
 					"machine.openshift.io/cluster-api-cluster":      clusterID,
 					"machine.openshift.io/cluster-api-machine-role": role,
 					"machine.openshift.io/cluster-api-machine-type": role,
-				},
+				}),
+				Annotations: mpool.MachineAnnotations,
 			},
 			Spec: machineapi.MachineSpec{
 				ProviderSpec: machineapi.ProviderSpec{
@@ -65,6 +77,17 @@ func Machines(clusterID string, config *types.InstallConfig, subnets map[string]
 	return machines, nil
 }
 
+// machineLabels returns the labels of a Machine or MachineSet object of the
+// pool, adding the machine labels of the pool to the installer labels.
+func machineLabels(mpool *ibmcloud.MachinePool, labels map[string]string) map[string]string {
+	for key, value := range mpool.MachineLabels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
 func provider(clusterID string,
 	platform *ibmcloud.Platform,
 	subnets map[string]string,
@@ -79,7 +102,7 @@ func provider(clusterID string,
 	if platform.VPCName != "" {
 		vpc = platform.VPCName
 	} else {
-		vpc = fmt.Sprintf("%s-vpc", clusterID)
+		vpc = names.VPCName(clusterID)
 	}
 
 	var resourceGroup string
@@ -95,12 +118,12 @@ func provider(clusterID string,
 		networkResourceGroup = platform.NetworkResourceGroupName
 	}
 
-	subnet, err := getSubnet(subnets, clusterID, role, az)
+	subnet, err := getSubnet(subnets, az)
 	if err != nil {
 		return nil, err
 	}
 
-	securityGroups, err := getSecurityGroupNames(clusterID, role)
+	securityGroups, err := getSecurityGroupNames(clusterID, platform.SecurityGroups, role)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +133,7 @@ func provider(clusterID string,
 		if mpool.DedicatedHosts[azIdx].Name != "" {
 			dedicatedHost = mpool.DedicatedHosts[azIdx].Name
 		} else {
-			dedicatedHost, err = getDedicatedHostNameForZone(clusterID, role, az)
+			dedicatedHost, err = names.DedicatedHostName(clusterID, names.Role(role), az)
 			if err != nil {
 				return nil, err
 			}
@@ -125,7 +148,7 @@ func provider(clusterID string,
 		VPC:                  vpc,
 		DedicatedHost:        dedicatedHost,
 		Tags:                 []ibmcloudprovider.TagSpecs{},
-		Image:                fmt.Sprintf("%s-rhcos", clusterID),
+		Image:                names.ImageName(clusterID),
 		NetworkResourceGroup: networkResourceGroup,
 		Profile:              mpool.InstanceType,
 		Region:               platform.Region,
@@ -141,54 +164,46 @@ func provider(clusterID string,
 	}, nil
 }
 
-func getDedicatedHostNameForZone(clusterID string, role string, zone string) (string, error) {
-	switch role {
-	case "master":
-		return fmt.Sprintf("%s-dhost-control-plane-%s", clusterID, zone), nil
-	case "worker":
-		return fmt.Sprintf("%s-dhost-compute-%s", clusterID, zone), nil
-	default:
-		return "", fmt.Errorf("invalid machine role %v", role)
-	}
-}
-
-func getSubnet(subnets map[string]string, clusterID string, role string, zone string) (string, error) {
-	if len(subnets) == 0 {
-		return getSubnetName(clusterID, role, zone)
-	}
-
+func getSubnet(subnets map[string]string, zone string) (string, error) {
 	if subnet, found := subnets[zone]; found {
 		return subnet, nil
 	}
 	return "", fmt.Errorf("no subnet found for %s", zone)
 }
 
-func getSubnetName(clusterID string, role string, zone string) (string, error) {
-	switch role {
-	case "master":
-		return fmt.Sprintf("%s-subnet-control-plane-%s", clusterID, zone), nil
-	case "worker":
-		return fmt.Sprintf("%s-subnet-compute-%s", clusterID, zone), nil
-	default:
-		return "", fmt.Errorf("invalid machine role %v", role)
+func getSecurityGroupNames(clusterID string, userSecurityGroups *ibmcloud.SecurityGroups, role string) ([]string, error) {
+	if userSecurityGroups != nil {
+		switch names.Role(role) {
+		case names.ControlPlaneRole:
+			return userSecurityGroups.ControlPlane, nil
+		case names.ComputeRole:
+			return userSecurityGroups.Compute, nil
+		default:
+			return nil, fmt.Errorf("invalid machine role %v", role)
+		}
 	}
-}
 
-func getSecurityGroupNames(clusterID string, role string) ([]string, error) {
-	switch role {
-	case "master":
-		return []string{
-			fmt.Sprintf("%s-sg-cluster-wide", clusterID),
-			fmt.Sprintf("%s-sg-openshift-net", clusterID),
-			fmt.Sprintf("%s-sg-control-plane", clusterID),
-			fmt.Sprintf("%s-sg-cp-internal", clusterID),
-		}, nil
-	case "worker":
-		return []string{
-			fmt.Sprintf("%s-sg-cluster-wide", clusterID),
-			fmt.Sprintf("%s-sg-openshift-net", clusterID),
-		}, nil
+	var kinds []names.SecurityGroup
+	switch names.Role(role) {
+	case names.ControlPlaneRole:
+		kinds = []names.SecurityGroup{
+			names.ClusterWideSecurityGroup,
+			names.OpenShiftNetworkSecurityGroup,
+			names.ControlPlaneSecurityGroup,
+			names.ControlPlaneInternalSecurityGroup,
+		}
+	case names.ComputeRole:
+		kinds = []names.SecurityGroup{
+			names.ClusterWideSecurityGroup,
+			names.OpenShiftNetworkSecurityGroup,
+		}
 	default:
 		return nil, fmt.Errorf("invalid machine role %v", role)
 	}
+
+	securityGroups := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		securityGroups = append(securityGroups, names.SecurityGroupName(clusterID, kind))
+	}
+	return securityGroups, nil
 }
@@ -2,6 +2,7 @@ package ibmcloud
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -11,8 +12,17 @@ import (
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
 )
 
+// acceleratorLabel is the node label the cluster autoscaler uses to identify
+// GPU nodes.
+const acceleratorLabel = "cluster-api/accelerator"
+
+// gpuProfileRE matches GPU (gx) instance profile names, such as gx2-8x64x1v100,
+// capturing the GPU model.
+var gpuProfileRE = regexp.MustCompile(`^gx\d+-\d+x\d+x\d+([a-z0-9]+)$`)
+
 // MachineSets returns a list of machinesets for a machinepool.
 func MachineSets(clusterID string, config *types.InstallConfig, subnets map[string]string, pool *types.MachinePool, role, userDataSecret string) ([]*machineapi.MachineSet, error) {
 	if configPlatform := config.Platform.Name(); configPlatform != ibmcloud.Name {
@@ -29,13 +39,23 @@ func MachineSets(clusterID string, config *types.InstallConfig, subnets map[stri
 	if pool.Replicas != nil {
 		total = *pool.Replicas
 	}
-	numOfAZs := int64(len(azs))
+	zoneReplicas := map[string]int32{}
+	for _, az := range MachineZones(azs, total) {
+		zoneReplicas[az]++
+	}
+	if len(subnets) == 0 {
+		// Generate the names of the installer-created subnets once for the
+		// whole pool.
+		var err error
+		subnets, err = names.SubnetNames(clusterID, names.Role(role), azs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var machinesets []*machineapi.MachineSet
 	for idx, az := range azs {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+		replicas := zoneReplicas[az]
 
 		provider, err := provider(clusterID, platform, subnets, mpool, idx, role, userDataSecret)
 		if err != nil {
@@ -50,9 +70,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, subnets map[stri
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: "openshift-machine-api",
 				Name:      name,
-				Labels: map[string]string{
+				Labels: machineLabels(mpool, map[string]string{
 					"machine.openshift.io/cluster-api-cluster": clusterID,
-				},
+				}),
+				Annotations: mpool.MachineAnnotations,
 			},
 			Spec: machineapi.MachineSetSpec{
 				Replicas: &replicas,
@@ -64,17 +85,22 @@ func MachineSets(clusterID string, config *types.InstallConfig, subnets map[stri
 				},
 				Template: machineapi.MachineTemplateSpec{
 					ObjectMeta: machineapi.ObjectMeta{
-						Labels: map[string]string{
+						Labels: machineLabels(mpool, map[string]string{
 							"machine.openshift.io/cluster-api-machineset":   name,
 							"machine.openshift.io/cluster-api-cluster":      clusterID,
 							"machine.openshift.io/cluster-api-machine-role": role,
 							"machine.openshift.io/cluster-api-machine-type": role,
-						},
+						}),
+						Annotations: mpool.MachineAnnotations,
 					},
 					Spec: machineapi.MachineSpec{
+						ObjectMeta: machineapi.ObjectMeta{
+							Labels: nodeLabels(mpool),
+						},
 						ProviderSpec: machineapi.ProviderSpec{
 							Value: &runtime.RawExtension{Object: provider},
 						},
+						Taints: mpool.NodeTaints,
 					},
 				},
 			},
@@ -83,3 +109,21 @@ func MachineSets(clusterID string, config *types.InstallConfig, subnets map[stri
 	}
 	return machinesets, nil
 }
+
+// nodeLabels returns the labels for the nodes of the pool, adding the
+// accelerator label for GPU profiles unless it is already set.
+func nodeLabels(mpool *ibmcloud.MachinePool) map[string]string {
+	match := gpuProfileRE.FindStringSubmatch(mpool.InstanceType)
+	if match == nil {
+		return mpool.NodeLabels
+	}
+	if _, ok := mpool.NodeLabels[acceleratorLabel]; ok {
+		return mpool.NodeLabels
+	}
+
+	labels := map[string]string{acceleratorLabel: match[1]}
+	for key, value := range mpool.NodeLabels {
+		labels[key] = value
+	}
+	return labels
+}
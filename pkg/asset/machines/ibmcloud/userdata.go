@@ -0,0 +1,56 @@
+package ibmcloud
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/vincent-petithory/dataurl"
+)
+
+// MaxUserDataSize is the maximum size in bytes of the user data of an IBM
+// Cloud VPC instance.
+const MaxUserDataSize = 64 * 1024
+
+// UserData returns the user data of the machines of a role. Pointer ignition
+// that exceeds the VPC instance user data limit is gzipped and embedded as a
+// base64 data URL in a config that Ignition replaces it with, so it still
+// fits. An error is returned if even the compressed config does not fit, which
+// would otherwise only fail when the instances are created.
+func UserData(role string, userData []byte) ([]byte, error) {
+	if len(userData) <= MaxUserDataSize {
+		return userData, nil
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(userData); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	config := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+			Config: igntypes.IgnitionConfig{
+				Replace: igntypes.Resource{
+					Compression: ignutil.StrToPtr("gzip"),
+					Source:      ignutil.StrToPtr(dataurl.EncodeBytes(compressed.Bytes())),
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxUserDataSize {
+		return nil, fmt.Errorf("%s user data is %d bytes, or %d bytes compressed, which exceeds the IBM Cloud VPC instance user data limit of %d bytes", role, len(userData), len(data), MaxUserDataSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package ibmcloud
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestUserData(t *testing.T) {
+	incompressible := make([]byte, MaxUserDataSize+1)
+	rand.New(rand.NewSource(1)).Read(incompressible)
+
+	cases := []struct {
+		name       string
+		userData   []byte
+		compressed bool
+		err        string
+	}{
+		{
+			name:     "pointer ignition",
+			userData: []byte(`{"ignition":{"version":"3.2.0"}}`),
+		},
+		{
+			name:     "at limit",
+			userData: []byte(strings.Repeat("a", MaxUserDataSize)),
+		},
+		{
+			name:       "over limit",
+			userData:   []byte(strings.Repeat("a", MaxUserDataSize+1)),
+			compressed: true,
+		},
+		{
+			name:     "over limit when compressed",
+			userData: incompressible,
+			err:      `^master user data is 65537 bytes, or \d+ bytes compressed, which exceeds the IBM Cloud VPC instance user data limit of 65536 bytes$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			userData, err := UserData("master", tc.userData)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			if !tc.compressed {
+				assert.Equal(t, tc.userData, userData)
+				return
+			}
+
+			assert.LessOrEqual(t, len(userData), MaxUserDataSize)
+			config := &igntypes.Config{}
+			if !assert.NoError(t, json.Unmarshal(userData, config)) {
+				return
+			}
+			assert.Equal(t, "gzip", *config.Ignition.Config.Replace.Compression)
+			source, err := dataurl.DecodeString(*config.Ignition.Config.Replace.Source)
+			if !assert.NoError(t, err) {
+				return
+			}
+			reader, err := gzip.NewReader(bytes.NewReader(source.Data))
+			if !assert.NoError(t, err) {
+				return
+			}
+			decompressed, err := io.ReadAll(reader)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.userData, decompressed)
+			}
+		})
+	}
+}
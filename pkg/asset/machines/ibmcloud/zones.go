@@ -1,19 +1,34 @@
 package ibmcloud
 
-import (
-	"context"
-
-	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
-)
+// MachineZones returns the zone of each of the replicas of a machine pool,
+// spreading them round-robin across the zones in order.
+func MachineZones(zones []string, replicas int64) []string {
+	machineZones := make([]string, replicas)
+	for idx := range machineZones {
+		machineZones[idx] = zones[machineZoneIndex(int64(idx), len(zones))]
+	}
+	return machineZones
+}
 
-// AvailabilityZones returns a list of supported zones for the specified region.
-func AvailabilityZones(region string) ([]string, error) {
-	ctx := context.TODO()
+// machineZoneIndex returns the index of the zone of the replica idx of a
+// machine pool with numZones zones.
+func machineZoneIndex(idx int64, numZones int) int {
+	return int(idx % int64(numZones))
+}
 
-	client, err := ibmcloud.NewClient()
-	if err != nil {
-		return nil, err
+// QuorumZone returns the zone hosting a majority of the replicas spread by
+// MachineZones, whose loss would lose etcd quorum for a control plane, or ""
+// if no single zone does. A single replica has no quorum to protect.
+func QuorumZone(zones []string, replicas int64) string {
+	if replicas <= 1 {
+		return ""
 	}
-
-	return client.GetVPCZonesForRegion(ctx, region)
+	counts := map[string]int64{}
+	for _, zone := range MachineZones(zones, replicas) {
+		counts[zone]++
+		if counts[zone] > replicas/2 {
+			return zone
+		}
+	}
+	return ""
 }
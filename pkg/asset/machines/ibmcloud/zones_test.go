@@ -0,0 +1,83 @@
+package ibmcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineZones(t *testing.T) {
+	cases := []struct {
+		name     string
+		zones    []string
+		replicas int64
+		expected []string
+	}{
+		{
+			name:     "one per zone",
+			zones:    []string{"us-south-1", "us-south-2", "us-south-3"},
+			replicas: 3,
+			expected: []string{"us-south-1", "us-south-2", "us-south-3"},
+		},
+		{
+			name:     "more replicas than zones",
+			zones:    []string{"us-south-1", "us-south-2"},
+			replicas: 5,
+			expected: []string{"us-south-1", "us-south-2", "us-south-1", "us-south-2", "us-south-1"},
+		},
+		{
+			name:     "fewer replicas than zones",
+			zones:    []string{"us-south-1", "us-south-2", "us-south-3"},
+			replicas: 2,
+			expected: []string{"us-south-1", "us-south-2"},
+		},
+		{
+			name:     "no replicas",
+			zones:    []string{"us-south-1"},
+			replicas: 0,
+			expected: []string{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MachineZones(tc.zones, tc.replicas))
+		})
+	}
+}
+
+func TestQuorumZone(t *testing.T) {
+	cases := []struct {
+		name     string
+		zones    []string
+		replicas int64
+		expected string
+	}{
+		{
+			name:     "three zones",
+			zones:    []string{"us-south-1", "us-south-2", "us-south-3"},
+			replicas: 3,
+		},
+		{
+			name:     "single zone",
+			zones:    []string{"us-south-1"},
+			replicas: 3,
+			expected: "us-south-1",
+		},
+		{
+			name:     "two zones",
+			zones:    []string{"us-south-1", "us-south-2"},
+			replicas: 3,
+			expected: "us-south-1",
+		},
+		{
+			name:     "single replica",
+			zones:    []string{"us-south-1"},
+			replicas: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, QuorumZone(tc.zones, tc.replicas))
+		})
+	}
+}
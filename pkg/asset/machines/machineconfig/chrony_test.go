@@ -0,0 +1,61 @@
+package machineconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestForChronyServers(t *testing.T) {
+	cases := []struct {
+		name             string
+		servers          []string
+		role             string
+		expectedName     string
+		expectedContents string
+	}{
+		{
+			name:         "single server for masters",
+			servers:      []string{"time.adn.networklayer.com"},
+			role:         "master",
+			expectedName: "99-master-ntp-servers",
+			expectedContents: "server time.adn.networklayer.com iburst\n" +
+				"driftfile /var/lib/chrony/drift\nmakestep 1.0 3\nrtcsync\nlogdir /var/log/chrony\n",
+		},
+		{
+			name:         "multiple servers for workers",
+			servers:      []string{"10.0.0.1", "ntp.example.com"},
+			role:         "worker",
+			expectedName: "99-worker-ntp-servers",
+			expectedContents: "server 10.0.0.1 iburst\nserver ntp.example.com iburst\n" +
+				"driftfile /var/lib/chrony/drift\nmakestep 1.0 3\nrtcsync\nlogdir /var/log/chrony\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc, err := ForChronyServers(tc.servers, tc.role)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expectedName, mc.Name)
+			assert.NotEqual(t, "99-"+tc.role+"-chrony", mc.Name)
+			assert.Equal(t, map[string]string{"machineconfiguration.openshift.io/role": tc.role}, mc.Labels)
+
+			ignConfig := igntypes.Config{}
+			if !assert.NoError(t, json.Unmarshal(mc.Spec.Config.Raw, &ignConfig)) {
+				return
+			}
+			if assert.Len(t, ignConfig.Storage.Files, 1) {
+				file := ignConfig.Storage.Files[0]
+				assert.Equal(t, "/etc/chrony.conf", file.Path)
+				contents, err := dataurl.DecodeString(*file.Contents.Source)
+				if assert.NoError(t, err) {
+					assert.Equal(t, tc.expectedContents, string(contents.Data))
+				}
+			}
+		})
+	}
+}
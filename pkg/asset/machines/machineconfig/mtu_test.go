@@ -0,0 +1,58 @@
+package machineconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestForNetworkMTU(t *testing.T) {
+	cases := []struct {
+		name             string
+		mtu              uint32
+		role             string
+		expectedName     string
+		expectedContents string
+	}{
+		{
+			name:             "jumbo frames for masters",
+			mtu:              9000,
+			role:             "master",
+			expectedName:     "99-master-network-mtu",
+			expectedContents: "[connection-ethernet-mtu]\nmatch-device=type:ethernet\nethernet.mtu=9000\n",
+		},
+		{
+			name:             "reduced mtu for workers",
+			mtu:              1400,
+			role:             "worker",
+			expectedName:     "99-worker-network-mtu",
+			expectedContents: "[connection-ethernet-mtu]\nmatch-device=type:ethernet\nethernet.mtu=1400\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc, err := ForNetworkMTU(tc.mtu, tc.role)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.expectedName, mc.Name)
+			assert.Equal(t, map[string]string{"machineconfiguration.openshift.io/role": tc.role}, mc.Labels)
+
+			ignConfig := igntypes.Config{}
+			if !assert.NoError(t, json.Unmarshal(mc.Spec.Config.Raw, &ignConfig)) {
+				return
+			}
+			if assert.Len(t, ignConfig.Storage.Files, 1) {
+				file := ignConfig.Storage.Files[0]
+				assert.Equal(t, "/etc/NetworkManager/conf.d/99-mtu.conf", file.Path)
+				contents, err := dataurl.DecodeString(*file.Contents.Source)
+				if assert.NoError(t, err) {
+					assert.Equal(t, tc.expectedContents, string(contents.Data))
+				}
+			}
+		})
+	}
+}
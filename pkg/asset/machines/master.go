@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
 	"github.com/openshift/installer/pkg/asset/machines/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/azure"
@@ -288,8 +289,10 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 	case ibmcloudtypes.Name:
 		subnets := map[string]string{}
+		var subnetMetas map[string]icibmcloud.Subnet
 		if len(ic.Platform.IBMCloud.ControlPlaneSubnets) > 0 {
-			subnetMetas, err := installConfig.IBMCloud.ControlPlaneSubnets(ctx)
+			var err error
+			subnetMetas, err = installConfig.IBMCloud.ControlPlaneSubnets(ctx)
 			if err != nil {
 				return err
 			}
@@ -300,13 +303,26 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		mpool := defaultIBMCloudMachinePoolPlatform()
 		mpool.Set(ic.Platform.IBMCloud.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.IBMCloud)
-		if len(mpool.Zones) == 0 {
-			azs, err := ibmcloud.AvailabilityZones(ic.Platform.IBMCloud.Region)
+		switch {
+		case len(mpool.Zones) > 0:
+		case len(subnetMetas) > 0:
+			// Spread the machines across the zones of the provided subnets.
+			mpool.Zones = icibmcloud.SubnetZones(subnetMetas)
+		default:
+			azs, err := installConfig.IBMCloud.VPCZones(ctx)
 			if err != nil {
 				return errors.Wrap(err, "failed to fetch availability zones")
 			}
 			mpool.Zones = azs
 		}
+		if len(subnetMetas) > 0 {
+			if err := icibmcloud.ValidateSubnetTopology(subnetMetas, ic.Platform.IBMCloud.VPCName, mpool.Zones); err != nil {
+				return errors.Wrap(err, "invalid control plane subnets")
+			}
+		}
+		if zone := ibmcloud.QuorumZone(mpool.Zones, *pool.Replicas); zone != "" {
+			logrus.Warnf("Zone %s hosts a majority of the %d control plane machines, so losing it loses etcd quorum. Provide control plane zones or subnets in at least three zones for zone resilience", zone, *pool.Replicas)
+		}
 		pool.Platform.IBMCloud = &mpool
 		machines, err = ibmcloud.Machines(clusterID.InfraID, ic, subnets, &pool, "master", masterUserDataSecretName)
 		if err != nil {
@@ -514,7 +530,15 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		return fmt.Errorf("invalid Platform")
 	}
 
-	data, err := userDataSecret(masterUserDataSecretName, mign.File.Data)
+	userData := mign.File.Data
+	if ic.Platform.Name() == ibmcloudtypes.Name {
+		userData, err = ibmcloud.UserData("master", userData)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := userDataSecret(masterUserDataSecretName, userData)
 	if err != nil {
 		return errors.Wrap(err, "failed to create user-data secret for master machines")
 	}
@@ -554,6 +578,25 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 		machineConfigs = append(machineConfigs, ignMultipath)
 	}
+	if ic.Platform.Name() == ibmcloudtypes.Name {
+		mpool := ibmcloudtypes.MachinePool{}
+		mpool.Set(ic.Platform.IBMCloud.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.IBMCloud)
+		if mpool.MTU != 0 {
+			ignMTU, err := machineconfig.ForNetworkMTU(mpool.MTU, "master")
+			if err != nil {
+				return errors.Wrap(err, "failed to create ignition for network MTU for master machines")
+			}
+			machineConfigs = append(machineConfigs, ignMTU)
+		}
+		if servers := ic.Platform.IBMCloud.NTPServers; len(servers) > 0 {
+			ignChrony, err := machineconfig.ForChronyServers(servers, "master")
+			if err != nil {
+				return errors.Wrap(err, "failed to create ignition for chrony for master machines")
+			}
+			machineConfigs = append(machineConfigs, ignChrony)
+		}
+	}
 	// The maximum number of networks supported on ServiceNetwork is two, one IPv4 and one IPv6 network.
 	// The cluster-network-operator handles the validation of this field.
 	// Reference: https://github.com/openshift/cluster-network-operator/blob/fc3e0e25b4cfa43e14122bdcdd6d7f2585017d75/pkg/network/cluster_config.go#L45-L52
@@ -31,6 +31,7 @@ import (
 	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
 	"github.com/openshift/installer/pkg/asset/machines/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/azure"
@@ -295,6 +296,25 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			machineConfigs = append(machineConfigs, ignMultipath)
 		}
+		if ic.Platform.Name() == ibmcloudtypes.Name {
+			mpool := ibmcloudtypes.MachinePool{}
+			mpool.Set(ic.Platform.IBMCloud.DefaultMachinePlatform)
+			mpool.Set(pool.Platform.IBMCloud)
+			if mpool.MTU != 0 {
+				ignMTU, err := machineconfig.ForNetworkMTU(mpool.MTU, "worker")
+				if err != nil {
+					return errors.Wrap(err, "failed to create ignition for network MTU for worker machines")
+				}
+				machineConfigs = append(machineConfigs, ignMTU)
+			}
+			if servers := ic.Platform.IBMCloud.NTPServers; len(servers) > 0 {
+				ignChrony, err := machineconfig.ForChronyServers(servers, "worker")
+				if err != nil {
+					return errors.Wrap(err, "failed to create ignition for chrony for worker machines")
+				}
+				machineConfigs = append(machineConfigs, ignChrony)
+			}
+		}
 		// The maximum number of networks supported on ServiceNetwork is two, one IPv4 and one IPv6 network.
 		// The cluster-network-operator handles the validation of this field.
 		// Reference: https://github.com/openshift/cluster-network-operator/blob/fc3e0e25b4cfa43e14122bdcdd6d7f2585017d75/pkg/network/cluster_config.go#L45-L52
@@ -553,8 +573,10 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 		case ibmcloudtypes.Name:
 			subnets := map[string]string{}
+			var subnetMetas map[string]icibmcloud.Subnet
 			if len(ic.Platform.IBMCloud.ComputeSubnets) > 0 {
-				subnetMetas, err := installConfig.IBMCloud.ComputeSubnets(ctx)
+				var err error
+				subnetMetas, err = installConfig.IBMCloud.ComputeSubnets(ctx)
 				if err != nil {
 					return err
 				}
@@ -565,13 +587,23 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			mpool := defaultIBMCloudMachinePoolPlatform()
 			mpool.Set(ic.Platform.IBMCloud.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.IBMCloud)
-			if len(mpool.Zones) == 0 {
-				azs, err := ibmcloud.AvailabilityZones(ic.Platform.IBMCloud.Region)
+			switch {
+			case len(mpool.Zones) > 0:
+			case len(subnetMetas) > 0:
+				// Spread the machines across the zones of the provided subnets.
+				mpool.Zones = icibmcloud.SubnetZones(subnetMetas)
+			default:
+				azs, err := installConfig.IBMCloud.VPCZones(ctx)
 				if err != nil {
 					return errors.Wrap(err, "failed to fetch availability zones")
 				}
 				mpool.Zones = azs
 			}
+			if len(subnetMetas) > 0 {
+				if err := icibmcloud.ValidateSubnetTopology(subnetMetas, ic.Platform.IBMCloud.VPCName, mpool.Zones); err != nil {
+					return errors.Wrap(err, "invalid compute subnets")
+				}
+			}
 			pool.Platform.IBMCloud = &mpool
 			sets, err := ibmcloud.MachineSets(clusterID.InfraID, ic, subnets, &pool, "worker", workerUserDataSecretName)
 			if err != nil {
@@ -687,7 +719,15 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 		}
 	}
 
-	data, err := userDataSecret(workerUserDataSecretName, wign.File.Data)
+	userData := wign.File.Data
+	if ic.Platform.Name() == ibmcloudtypes.Name {
+		userData, err = ibmcloud.UserData("worker", userData)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := userDataSecret(workerUserDataSecretName, userData)
 	if err != nil {
 		return errors.Wrap(err, "failed to create user-data secret for worker machines")
 	}
@@ -13,7 +13,6 @@ import (
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
-	ibmcloudmachines "github.com/openshift/installer/pkg/asset/machines/ibmcloud"
 	alibabacloudmanifests "github.com/openshift/installer/pkg/asset/manifests/alibabacloud"
 	"github.com/openshift/installer/pkg/asset/manifests/azure"
 	gcpmanifests "github.com/openshift/installer/pkg/asset/manifests/gcp"
@@ -216,7 +215,7 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 		compute.Set(installConfig.Config.WorkerMachinePool().Platform.IBMCloud)
 
 		if len(controlPlane.Zones) == 0 || len(compute.Zones) == 0 {
-			zones, err := ibmcloudmachines.AvailabilityZones(installConfig.Config.IBMCloud.Region)
+			zones, err := installConfig.IBMCloud.VPCZones(context.TODO())
 			if err != nil {
 				return errors.Wrapf(err, "could not get availability zones for %s", installConfig.Config.IBMCloud.Region)
 			}
@@ -237,6 +236,7 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 			subnetNames,
 			controlPlane.Zones,
 			compute.Zones,
+			installConfig.Config.Platform.IBMCloud.SecurityGroups,
 		)
 		if err != nil {
 			return errors.Wrap(err, "could not create cloud provider config")
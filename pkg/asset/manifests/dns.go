@@ -15,7 +15,6 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
-	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
 	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	"github.com/openshift/installer/pkg/types"
 	alibabacloudtypes "github.com/openshift/installer/pkg/types/alibabacloud"
@@ -177,7 +176,12 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 		config.Spec.PrivateZone = &configv1.DNSZone{ID: privateZoneID}
 
 	case ibmcloudtypes.Name:
-		client, err := icibmcloud.NewClient()
+		if installConfig.Config.Platform.IBMCloud.IsUserProvisionedDNS() {
+			// Without zones the ingress operator does not manage any DNS
+			// records, leaving *.apps to the user.
+			break
+		}
+		client, err := installConfig.IBMCloud.Client()
 		if err != nil {
 			return errors.Wrap(err, "failed to get IBM Cloud client")
 		}
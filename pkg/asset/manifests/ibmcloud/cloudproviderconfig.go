@@ -2,10 +2,12 @@ package ibmcloud
 
 import (
 	"bytes"
-	"fmt"
 	"sort"
 	"strings"
 	"text/template"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
 )
 
 // https://github.com/kubernetes/kubernetes/blob/368ee4bb8ee7a0c18431cd87ee49f0c890aa53e5/staging/src/k8s.io/legacy-cloud-providers/gce/gce.go#L188
@@ -33,12 +35,13 @@ type provider struct {
 	G2VPCName                string `gcfg:"g2VpcName"`
 	G2WorkerServiceAccountID string `gcfg:"g2workerServiceAccountID"`
 	G2VPCSubnetNames         string `gcfg:"g2VpcSubnetNames"`
+	G2LBSecurityGroupNames   string `gcfg:"g2LbSecurityGroupNames"`
 }
 
 // CloudProviderConfig generates the cloud provider config for the IBMCloud platform.
-func CloudProviderConfig(infraID string, accountID string, region string, resourceGroupName string, vpcName string, subnets []string, controlPlaneZones []string, computeZones []string) (string, error) {
+func CloudProviderConfig(infraID string, accountID string, region string, resourceGroupName string, vpcName string, subnets []string, controlPlaneZones []string, computeZones []string, securityGroups *ibmcloud.SecurityGroups) (string, error) {
 	if vpcName == "" {
-		vpcName = fmt.Sprintf("%s-vpc", infraID)
+		vpcName = names.VPCName(infraID)
 	}
 
 	var subnetNames string
@@ -65,6 +68,7 @@ func CloudProviderConfig(infraID string, accountID string, region string, resour
 			G2VPCName:                vpcName,
 			G2WorkerServiceAccountID: accountID,
 			G2VPCSubnetNames:         subnetNames,
+			G2LBSecurityGroupNames:   getLBSecurityGroupNames(securityGroups),
 		},
 	}
 	buf := &bytes.Buffer{}
@@ -79,18 +83,29 @@ func CloudProviderConfig(infraID string, accountID string, region string, resour
 func getVpcSubnetNames(infraID string, controlPlaneZones []string, computeZones []string) string {
 	var subnetNames []string
 
-	for cpIndex := range controlPlaneZones {
-		// Add Control Plane subnet
-		subnetNames = append(subnetNames, fmt.Sprintf("%s-subnet-control-plane-%s", infraID, controlPlaneZones[cpIndex]))
+	// The roles are known, so generating the names cannot fail.
+	controlPlaneSubnets, _ := names.SubnetNames(infraID, names.ControlPlaneRole, controlPlaneZones)
+	computeSubnets, _ := names.SubnetNames(infraID, names.ComputeRole, computeZones)
+	for _, subnetName := range controlPlaneSubnets {
+		subnetNames = append(subnetNames, subnetName)
 	}
-	for comIndex := range computeZones {
-		// Add Compute subnet
-		subnetNames = append(subnetNames, fmt.Sprintf("%s-subnet-compute-%s", infraID, computeZones[comIndex]))
+	for _, subnetName := range computeSubnets {
+		subnetNames = append(subnetNames, subnetName)
 	}
 	sort.Strings(subnetNames)
 	return strings.Join(subnetNames, ",")
 }
 
+// Generate a string of Security Group names the CCM attaches to the load
+// balancers it creates for services. Only existing load balancer security
+// groups are passed; an empty string leaves the CCM on its default behavior.
+func getLBSecurityGroupNames(securityGroups *ibmcloud.SecurityGroups) string {
+	if securityGroups == nil {
+		return ""
+	}
+	return strings.Join(securityGroups.LoadBalancer, ",")
+}
+
 var configTmpl = `[global]
 version = {{.Global.Version}}
 [kubernetes]
@@ -105,5 +120,8 @@ g2ResourceGroupName = {{.Provider.G2ResourceGroupName}}
 g2VpcName = {{.Provider.G2VPCName}}
 g2workerServiceAccountID = {{.Provider.G2WorkerServiceAccountID}}
 g2VpcSubnetNames = {{.Provider.G2VPCSubnetNames}}
+{{- if ne .Provider.G2LBSecurityGroupNames "" }}
+g2LbSecurityGroupNames = {{.Provider.G2LBSecurityGroupNames}}
+{{- end }}
 
 `
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
 func TestCloudProviderConfig(t *testing.T) {
@@ -48,6 +50,41 @@ g2VpcName = ocp4-hf4vtt-vpc
 g2workerServiceAccountID = 1e1f75646aef447814a6d907cc83fb3c
 g2VpcSubnetNames = existing-subnet-control-plane-eu-gb-1,existing-subnet-control-plane-eu-gb-2,existing-subnet-control-plane-eu-gb-3,existing-subnet-compute-eu-gb-1,existing-subnet-compute-eu-gb-2,existing-subnet-compute-eu-gb-3
 
+`
+
+	existingSecurityGroupsConfig := `[global]
+version = 1.1.0
+[kubernetes]
+config-file = ""
+[provider]
+accountID = 1e1f75646aef447814a6d907cc83fb3c
+clusterID = ocp4-hf4vtt
+cluster-default-provider = g2
+region = eu-gb
+g2Credentials = /etc/vpc/ibmcloud_api_key
+g2ResourceGroupName = ocp4-hf4vtt-rg
+g2VpcName = existing-vpc
+g2workerServiceAccountID = 1e1f75646aef447814a6d907cc83fb3c
+g2VpcSubnetNames = existing-subnet-control-plane-eu-gb-1,existing-subnet-control-plane-eu-gb-2,existing-subnet-control-plane-eu-gb-3,existing-subnet-compute-eu-gb-1,existing-subnet-compute-eu-gb-2,existing-subnet-compute-eu-gb-3
+g2LbSecurityGroupNames = existing-sg-lb-1,existing-sg-lb-2
+
+`
+
+	existingSecurityGroupsNoLBConfig := `[global]
+version = 1.1.0
+[kubernetes]
+config-file = ""
+[provider]
+accountID = 1e1f75646aef447814a6d907cc83fb3c
+clusterID = ocp4-hf4vtt
+cluster-default-provider = g2
+region = eu-gb
+g2Credentials = /etc/vpc/ibmcloud_api_key
+g2ResourceGroupName = ocp4-hf4vtt-rg
+g2VpcName = existing-vpc
+g2workerServiceAccountID = 1e1f75646aef447814a6d907cc83fb3c
+g2VpcSubnetNames = existing-subnet-control-plane-eu-gb-1,existing-subnet-control-plane-eu-gb-2,existing-subnet-control-plane-eu-gb-3,existing-subnet-compute-eu-gb-1,existing-subnet-compute-eu-gb-2,existing-subnet-compute-eu-gb-3
+
 `
 
 	eugbZones := []string{"eu-gb-1", "eu-gb-2", "eu-gb-3"}
@@ -63,6 +100,7 @@ g2VpcSubnetNames = existing-subnet-control-plane-eu-gb-1,existing-subnet-control
 		subnets           []string
 		cpZones           []string
 		computeZones      []string
+		securityGroups    *ibmcloud.SecurityGroups
 		expectedConfig    string
 	}{
 		{
@@ -89,11 +127,44 @@ g2VpcSubnetNames = existing-subnet-control-plane-eu-gb-1,existing-subnet-control
 			computeZones:      eugbZones,
 			expectedConfig:    existingSubnetConfig,
 		},
+		{
+			name:              "existing security groups config",
+			infraID:           "ocp4-hf4vtt",
+			accountID:         accountID,
+			region:            "eu-gb",
+			resourceGroupName: "ocp4-hf4vtt-rg",
+			vpcName:           "existing-vpc",
+			subnets:           existingSubnets,
+			cpZones:           eugbZones,
+			computeZones:      eugbZones,
+			securityGroups: &ibmcloud.SecurityGroups{
+				ControlPlane: []string{"existing-sg-control-plane"},
+				Compute:      []string{"existing-sg-compute"},
+				LoadBalancer: []string{"existing-sg-lb-1", "existing-sg-lb-2"},
+			},
+			expectedConfig: existingSecurityGroupsConfig,
+		},
+		{
+			name:              "existing security groups without load balancer config",
+			infraID:           "ocp4-hf4vtt",
+			accountID:         accountID,
+			region:            "eu-gb",
+			resourceGroupName: "ocp4-hf4vtt-rg",
+			vpcName:           "existing-vpc",
+			subnets:           existingSubnets,
+			cpZones:           eugbZones,
+			computeZones:      eugbZones,
+			securityGroups: &ibmcloud.SecurityGroups{
+				ControlPlane: []string{"existing-sg-control-plane"},
+				Compute:      []string{"existing-sg-compute"},
+			},
+			expectedConfig: existingSecurityGroupsNoLBConfig,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualConfig, err := CloudProviderConfig(tc.infraID, tc.accountID, tc.region, tc.resourceGroupName, tc.vpcName, tc.subnets, tc.cpZones, tc.computeZones)
+			actualConfig, err := CloudProviderConfig(tc.infraID, tc.accountID, tc.region, tc.resourceGroupName, tc.vpcName, tc.subnets, tc.cpZones, tc.computeZones, tc.securityGroups)
 			assert.NoError(t, err, "failed to create cloud provider config")
 			assert.Equal(t, tc.expectedConfig, actualConfig, "unexpected cloud provider config")
 		})
@@ -196,13 +196,15 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 	case ibmcloud.Name:
 		config.Spec.PlatformSpec.Type = configv1.IBMCloudPlatformType
 		var cisInstanceCRN, dnsInstanceCRN string
-		if installConfig.Config.Publish == types.InternalPublishingStrategy {
+		switch {
+		case installConfig.Config.Platform.IBMCloud.IsUserProvisionedDNS():
+		case installConfig.Config.Publish == types.InternalPublishingStrategy:
 			dnsInstance, err := installConfig.IBMCloud.DNSInstance(context.TODO())
 			if err != nil {
 				return errors.Wrap(err, "cannot retrieve IBM DNS Services instance CRN")
 			}
 			dnsInstanceCRN = dnsInstance.CRN
-		} else {
+		default:
 			crn, err := installConfig.IBMCloud.CISInstanceCRN(context.TODO())
 			if err != nil {
 				return errors.Wrap(err, "cannot retrieve IBM Cloud Internet Services instance CRN")
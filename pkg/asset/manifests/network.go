@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/powervs"
 )
 
@@ -25,6 +27,10 @@ var (
 	// Cluster Network MTU for AWS Local Zone deployments on edge machine pools.
 	ovnKNetworkMtuEdge   uint32 = 1200
 	ocpSDNNetworkMtuEdge uint32 = 1250
+	// Overhead of the network plugin encapsulation subtracted from the
+	// machine MTU for the cluster network MTU.
+	ovnKNetworkMtuOverhead   uint32 = 100
+	ocpSDNNetworkMtuOverhead uint32 = 50
 )
 
 // We need to manually create our CRDs first, so we can create the
@@ -137,6 +143,18 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 			})
 		}
 
+	case ibmcloud.Name:
+		cnoDefCfg, exists, err := no.generateDefaultNetworkConfigIBMCloud(installConfig)
+		if err != nil {
+			return err
+		}
+		if exists {
+			no.FileList = append(no.FileList, &asset.File{
+				Filename: cnoCfgFilename,
+				Data:     cnoDefCfg,
+			})
+		}
+
 	case powervs.Name:
 		if netConfig.NetworkType == "OVNKubernetes" {
 			ovnConfig, err := OvnKubeConfig(clusterNet, serviceNet, true)
@@ -235,3 +253,51 @@ func (no *Networking) generateDefaultNetworkConfigAWSEdge(ic *installconfig.Inst
 
 	return cnoConfig, true, nil
 }
+
+// Check if the machine pools set an MTU, and generate the CNO object to set
+// DefaultNetwork for CNI with the matching cluster network MTU. Every machine
+// pool uses the same MTU, which is validated with the install config.
+func (no *Networking) generateDefaultNetworkConfigIBMCloud(ic *installconfig.InstallConfig) ([]byte, bool, error) {
+	var defNetCfg *operatorv1.DefaultNetworkDefinition
+
+	mpool := ibmcloud.MachinePool{}
+	mpool.Set(ic.Config.Platform.IBMCloud.DefaultMachinePlatform)
+	if ic.Config.ControlPlane != nil {
+		mpool.Set(ic.Config.ControlPlane.Platform.IBMCloud)
+	}
+	if mpool.MTU == 0 {
+		return nil, false, nil
+	}
+
+	netConfig := ic.Config.Networking
+	switch netConfig.NetworkType {
+	case string(operatorv1.NetworkTypeOVNKubernetes):
+		mtu := mpool.MTU - ovnKNetworkMtuOverhead
+		defNetCfg = &operatorv1.DefaultNetworkDefinition{
+			Type: operatorv1.NetworkTypeOVNKubernetes,
+			OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+				MTU: &mtu,
+			},
+		}
+	case string(operatorv1.NetworkTypeOpenShiftSDN):
+		mtu := mpool.MTU - ocpSDNNetworkMtuOverhead
+		defNetCfg = &operatorv1.DefaultNetworkDefinition{
+			Type: operatorv1.NetworkTypeOpenShiftSDN,
+			OpenShiftSDNConfig: &operatorv1.OpenShiftSDNConfig{
+				MTU: &mtu,
+			},
+		}
+	default:
+		// Third-party network plugins are configured through their own
+		// manifests, which must account for the machine MTU.
+		logrus.Warnf("The cluster network MTU is not derived from the machine MTU %d for the %s network type", mpool.MTU, netConfig.NetworkType)
+		return nil, false, nil
+	}
+
+	cnoConfig, err := no.generateDefaultNetworkConfig(defNetCfg)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "cannot marshal DefaultNetworkConfig for %s", netConfig.NetworkType)
+	}
+
+	return cnoConfig, true, nil
+}
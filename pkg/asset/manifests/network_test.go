@@ -0,0 +1,96 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+func TestGenerateDefaultNetworkConfigIBMCloud(t *testing.T) {
+	mtu := func(mtu uint32) *uint32 { return &mtu }
+
+	cases := []struct {
+		name                   string
+		networkType            string
+		defaultMachinePlatform *ibmcloud.MachinePool
+		controlPlane           *ibmcloud.MachinePool
+		expectedNetwork        *operatorv1.DefaultNetworkDefinition
+	}{
+		{
+			name:        "no mtu",
+			networkType: string(operatorv1.NetworkTypeOVNKubernetes),
+		},
+		{
+			name:                   "ovn kubernetes",
+			networkType:            string(operatorv1.NetworkTypeOVNKubernetes),
+			defaultMachinePlatform: &ibmcloud.MachinePool{MTU: 9000},
+			expectedNetwork: &operatorv1.DefaultNetworkDefinition{
+				Type:                operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{MTU: mtu(8900)},
+			},
+		},
+		{
+			name:         "openshift sdn",
+			networkType:  string(operatorv1.NetworkTypeOpenShiftSDN),
+			controlPlane: &ibmcloud.MachinePool{MTU: 9000},
+			expectedNetwork: &operatorv1.DefaultNetworkDefinition{
+				Type:               operatorv1.NetworkTypeOpenShiftSDN,
+				OpenShiftSDNConfig: &operatorv1.OpenShiftSDNConfig{MTU: mtu(8950)},
+			},
+		},
+		{
+			name:                   "control plane overrides default machine platform",
+			networkType:            string(operatorv1.NetworkTypeOVNKubernetes),
+			defaultMachinePlatform: &ibmcloud.MachinePool{MTU: 9000},
+			controlPlane:           &ibmcloud.MachinePool{MTU: 1400},
+			expectedNetwork: &operatorv1.DefaultNetworkDefinition{
+				Type:                operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{MTU: mtu(1300)},
+			},
+		},
+		{
+			name:                   "third-party network type",
+			networkType:            "Calico",
+			defaultMachinePlatform: &ibmcloud.MachinePool{MTU: 9000},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := installconfig.MakeAsset(&types.InstallConfig{
+				Networking: &types.Networking{NetworkType: tc.networkType},
+				ControlPlane: &types.MachinePool{
+					Platform: types.MachinePoolPlatform{IBMCloud: tc.controlPlane},
+				},
+				Platform: types.Platform{
+					IBMCloud: &ibmcloud.Platform{
+						DefaultMachinePlatform: tc.defaultMachinePlatform,
+					},
+				},
+			})
+
+			no := &Networking{}
+			data, exists, err := no.generateDefaultNetworkConfigIBMCloud(ic)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if tc.expectedNetwork == nil {
+				assert.False(t, exists)
+				return
+			}
+			if !assert.True(t, exists) {
+				return
+			}
+
+			network := &operatorv1.Network{}
+			if assert.NoError(t, yaml.Unmarshal(data, network)) {
+				assert.Equal(t, *tc.expectedNetwork, network.Spec.DefaultNetwork)
+			}
+		})
+	}
+}
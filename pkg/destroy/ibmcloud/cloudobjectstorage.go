@@ -1,11 +1,10 @@
 package ibmcloud
 
 import (
-	"fmt"
-	"net/http"
-
 	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
 )
 
 const (
@@ -56,7 +55,7 @@ func (o *ClusterUninstaller) reclaimCOSInstanceReclamation(reclamationID string)
 	_, response, err := o.controllerSvc.RunReclamationActionWithContext(ctx, options)
 	if err != nil {
 		// If reclaim attempt failed because the reclamation doesn't exist (404) don't return an error
-		if response != nil && response.StatusCode == http.StatusNotFound {
+		if isNotFound(response, err) {
 			o.Logger.Debugf("Reclamation not found, it has likely already been reclaimed %s", reclamationID)
 			return nil
 		}
@@ -82,25 +81,39 @@ func (o *ClusterUninstaller) listCOSInstances() (cloudResources, error) {
 	options.SetResourceGroupID(resourceGroupID)
 	options.SetResourceID(cosResourceID)
 	options.SetType("service_instance")
-	resources, _, err := o.controllerSvc.ListResourceInstancesWithContext(ctx, options)
-	if err != nil {
-		return nil, errors.Wrapf(err, "Failed to list COS instances")
-	}
+	options.SetLimit(100)
 
 	result := []cloudResource{}
-	for _, instance := range resources.Resources {
-		// Match the COS instances created by both the installer and the
-		// cluster-image-registry-operator.
-		if fmt.Sprintf("%s-cos", o.InfraID) == *instance.Name ||
-			fmt.Sprintf("%s-image-registry", o.InfraID) == *instance.Name {
-			result = append(result, cloudResource{
-				key:      *instance.ID,
-				name:     *instance.Name,
-				status:   *instance.State,
-				typeName: cosTypeName,
-				id:       *instance.ID,
-			})
+	for {
+		resources, _, err := o.controllerSvc.ListResourceInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to list COS instances")
+		}
+
+		for _, instance := range resources.Resources {
+			// Match the COS instances created by both the installer and the
+			// cluster-image-registry-operator.
+			if names.COSInstanceName(o.InfraID) == *instance.Name ||
+				names.ImageRegistryCOSInstanceName(o.InfraID) == *instance.Name {
+				result = append(result, cloudResource{
+					key:      *instance.ID,
+					name:     *instance.Name,
+					status:   *instance.State,
+					typeName: cosTypeName,
+					id:       *instance.ID,
+				})
+			}
+		}
+
+		// Set the start for the next page, or exit the loop after the last page.
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to get next page of COS instances")
+		}
+		if start == nil {
+			break
 		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -116,7 +129,7 @@ func (o *ClusterUninstaller) deleteCOSInstance(item cloudResource) error {
 	details, err := o.controllerSvc.DeleteResourceInstanceWithContext(ctx, options)
 	if err != nil {
 		// If the deletion attempt failed because the COS instance doesn't exist (404) don't return an error
-		if details != nil && details.StatusCode == http.StatusNotFound {
+		if isNotFound(details, err) {
 			return nil
 		}
 		return errors.Wrapf(err, "Failed to delete COS Instance %s", item.name)
@@ -162,7 +175,7 @@ func (o *ClusterUninstaller) destroyCOSInstances() error {
 	}
 
 	if items = o.getPendingItems(cosTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -184,7 +197,7 @@ func (o *ClusterUninstaller) COSInstanceID() (string, error) {
 
 	// Locate the installer's COS instance by name.
 	for _, instance := range instanceList {
-		if instance.name == fmt.Sprintf("%s-cos", o.InfraID) {
+		if instance.name == names.COSInstanceName(o.InfraID) {
 			o.cosInstanceID = instance.id
 			return instance.id, nil
 		}
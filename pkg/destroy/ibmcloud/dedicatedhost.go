@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/go-sdk-core/v5/core"
@@ -28,22 +27,35 @@ func (o *ClusterUninstaller) listDedicatedHosts() (cloudResources, error) {
 
 	options := o.vpcSvc.NewListDedicatedHostsOptions()
 	options.SetResourceGroupID(resourceGroupID)
-	resources, _, err := o.vpcSvc.ListDedicatedHostsWithContext(ctx, options)
-	if err != nil {
-		return nil, err
-	}
+	options.SetLimit(100)
 
 	result := []cloudResource{}
-	for _, dhost := range resources.DedicatedHosts {
-		if strings.HasPrefix(*dhost.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *dhost.ID,
-				name:     *dhost.Name,
-				status:   *dhost.State,
-				typeName: dedicatedHostTypeName,
-				id:       *dhost.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListDedicatedHostsWithContext(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dhost := range resources.DedicatedHosts {
+			if strings.HasPrefix(*dhost.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *dhost.ID,
+					name:     *dhost.Name,
+					status:   *dhost.State,
+					typeName: dedicatedHostTypeName,
+					id:       *dhost.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
 		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -63,22 +75,35 @@ func (o *ClusterUninstaller) listDedicatedHostGroups() (cloudResources, error) {
 
 	options := o.vpcSvc.NewListDedicatedHostGroupsOptions()
 	options.SetResourceGroupID(resourceGroupID)
-	resources, _, err := o.vpcSvc.ListDedicatedHostGroupsWithContext(ctx, options)
-	if err != nil {
-		return nil, err
-	}
+	options.SetLimit(100)
 
 	result := []cloudResource{}
-	for _, dgroup := range resources.Groups {
-		if strings.HasPrefix(*dgroup.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *dgroup.ID,
-				name:     *dgroup.Name,
-				status:   "",
-				typeName: dedicatedHostGroupTypeName,
-				id:       *dgroup.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListDedicatedHostGroupsWithContext(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dgroup := range resources.Groups {
+			if strings.HasPrefix(*dgroup.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *dgroup.ID,
+					name:     *dgroup.Name,
+					status:   "",
+					typeName: dedicatedHostGroupTypeName,
+					id:       *dgroup.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
 		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -113,14 +138,14 @@ func (o *ClusterUninstaller) deleteDedicatedHost(item cloudResource) error {
 	deleteOpts := o.vpcSvc.NewDeleteDedicatedHostOptions(item.id)
 	details, err := o.vpcSvc.DeleteDedicatedHostWithContext(ctx, deleteOpts)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted dedicated host %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete dedicated host %q", item.name)
 	}
 
@@ -137,14 +162,14 @@ func (o *ClusterUninstaller) deleteDedicatedHostGroup(item cloudResource) error
 	options := o.vpcSvc.NewDeleteDedicatedHostGroupOptions(item.id)
 	details, err := o.vpcSvc.DeleteDedicatedHostGroupWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted dedicated host group %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete dedicated host group %q", item.name)
 	}
 
@@ -191,7 +216,7 @@ func (o *ClusterUninstaller) destroyDedicatedHosts() error {
 	}
 
 	if items = o.getPendingItems(dedicatedHostTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -219,7 +244,7 @@ func (o *ClusterUninstaller) destroyDedicatedHostGroups() error {
 	}
 
 	if items = o.getPendingItems(dedicatedHostGroupTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -58,11 +57,11 @@ func (o *ClusterUninstaller) deleteDisk(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteVolumeOptions(item.id)
 	details, err := o.vpcSvc.DeleteVolumeWithContext(ctx, options)
 
-	if err != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete disk name=%s, id=%s.If this error continues to persist for more than 20 minutes then please try to manually cleanup the volume using - ibmcloud is vold %s", item.name, item.id, item.id)
 	}
 
-	if err != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted disk %s", item.id)
@@ -81,7 +80,7 @@ func (o *ClusterUninstaller) waitForDiskDeletion(item cloudResource) error {
 		volumeOptions := o.vpcSvc.NewGetVolumeOptions(item.id)
 		_, response, err := o.vpcSvc.GetVolumeWithContext(ctx, volumeOptions)
 		// Keep retry, until GetVolume returns volume not found
-		if err != nil && response.StatusCode == http.StatusNotFound {
+		if isNotFound(response, err) {
 			skip = true
 			return nil, skip
 		}
@@ -122,7 +121,7 @@ func (o *ClusterUninstaller) destroyDisks() error {
 	}
 
 	if items = o.getPendingItems("disk"); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
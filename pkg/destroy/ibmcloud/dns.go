@@ -2,7 +2,6 @@ package ibmcloud
 
 import (
 	"fmt"
-	"net/http"
 	"regexp"
 
 	"github.com/pkg/errors"
@@ -113,14 +112,14 @@ func (o *ClusterUninstaller) deleteCISDNSRecord(item cloudResource) error {
 	options := o.dnsRecordsSvc.NewDeleteDnsRecordOptions(item.id)
 	_, details, err := o.dnsRecordsSvc.DeleteDnsRecordWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted DNS record %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete DNS record %s", item.name)
 	}
 
@@ -136,14 +135,14 @@ func (o *ClusterUninstaller) deleteDNSSvcDNSRecord(item cloudResource) error {
 	options := o.dnsServicesSvc.NewDeleteResourceRecordOptions(o.DNSInstanceID, o.zoneID, item.id)
 	details, err := o.dnsServicesSvc.DeleteResourceRecordWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted DNS record %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete DNS record %s", item.name)
 	}
 	return nil
@@ -179,7 +178,7 @@ func (o *ClusterUninstaller) destroyDNSRecords() error {
 	}
 
 	if items = o.getPendingItems(dnsRecordTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
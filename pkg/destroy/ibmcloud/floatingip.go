@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -16,24 +15,42 @@ func (o *ClusterUninstaller) listFloatingIPs() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListFloatingIpsOptions()
-	resources, _, err := o.vpcSvc.ListFloatingIpsWithContext(ctx, options)
-
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list floating IPs")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListFloatingIpsOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, floatingIPs := range resources.FloatingIps {
-		if strings.Contains(*floatingIPs.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *floatingIPs.ID,
-				name:     *floatingIPs.Name,
-				status:   *floatingIPs.Status,
-				typeName: floatingIPTypeName,
-				id:       *floatingIPs.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListFloatingIpsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list floating IPs")
+		}
+
+		for _, floatingIPs := range resources.FloatingIps {
+			if strings.Contains(*floatingIPs.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *floatingIPs.ID,
+					name:     *floatingIPs.Name,
+					status:   *floatingIPs.Status,
+					typeName: floatingIPTypeName,
+					id:       *floatingIPs.ID,
+				})
+			}
 		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -52,14 +69,20 @@ func (o *ClusterUninstaller) deleteFloatingIP(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteFloatingIPOptions(item.id)
 	details, err := o.vpcSvc.DeleteFloatingIPWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted floating IP %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the floating IP are still being deleted
+		o.Logger.Debugf("Floating IP %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete floating IP %s", item.name)
 	}
 
@@ -90,7 +113,7 @@ func (o *ClusterUninstaller) destroyFloatingIPs() error {
 	}
 
 	if items = o.getPendingItems(floatingIPTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/platform-services-go-sdk/iampolicymanagementv1"
@@ -82,14 +81,14 @@ func (o *ClusterUninstaller) deleteIAMAuthorization(item cloudResource) error {
 	options := o.iamPolicyManagementSvc.NewDeletePolicyOptions(item.id)
 	details, err := o.iamPolicyManagementSvc.DeletePolicyWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted IAM authorization %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete IAM authorization %s", item.name)
 	}
 
@@ -119,7 +118,7 @@ func (o *ClusterUninstaller) destroyIAMAuthorizations() error {
 	}
 
 	if items = o.getPendingItems(iamAuthorizationTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
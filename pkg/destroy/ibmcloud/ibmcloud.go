@@ -135,18 +135,22 @@ func (o *ClusterUninstaller) destroyCluster() error {
 		{name: "Instances", execute: o.destroyInstances},
 		{name: "Disks", execute: o.destroyDisks},
 	}, {
-		// LB's must occur before Subnet cleanup
+		// LB's must occur before Security Group cleanup
 		{name: "Load Balancers", execute: o.destroyLoadBalancers},
 	}, {
+		// Security Groups must occur before Subnet cleanup
+		{name: "Security Groups", execute: o.destroySecurityGroups},
+	}, {
+		// Subnets must occur before Public Gateway cleanup
 		{name: "Subnets", execute: o.destroySubnets},
 	}, {
 		// Public Gateways must occur before FIP's cleanup
-		// Security Groups must occur before VPC cleanup
-		{name: "Images", execute: o.destroyImages},
 		{name: "Public Gateways", execute: o.destroyPublicGateways},
-		{name: "Security Groups", execute: o.destroySecurityGroups},
 	}, {
 		{name: "Floating IPs", execute: o.destroyFloatingIPs},
+	}, {
+		// Images must occur before VPC cleanup
+		{name: "Images", execute: o.destroyImages},
 	}, {
 		{name: "Dedicated Hosts", execute: o.destroyDedicatedHosts},
 		{name: "VPCs", execute: o.destroyVPCs},
@@ -195,12 +199,21 @@ func (o *ClusterUninstaller) executeStageFunction(f struct {
 }, errCh chan error, wg *sync.WaitGroup) error {
 	defer wg.Done()
 
+	// Log the number of pending items whenever it changes, so the progress of
+	// each resource type is visible without debug logging.
+	lastPending := pendingItemsError(-1)
 	err := wait.PollImmediateInfinite(
 		time.Second*10,
 		func() (bool, error) {
 			ferr := f.execute()
 			if ferr != nil {
-				o.Logger.Debugf("%s: %v", f.name, ferr)
+				var pending pendingItemsError
+				if errors.As(ferr, &pending) && pending != lastPending {
+					o.Logger.Infof("%s: %d pending", f.name, pending)
+					lastPending = pending
+				} else {
+					o.Logger.Debugf("%s: %v", f.name, ferr)
+				}
 				return false, nil
 			}
 			return true, nil
@@ -423,6 +436,20 @@ func isNoOp(err *ibmError) bool {
 	return err.Status == http.StatusNotFound
 }
 
+// isNotFound returns whether a failed call was rejected because the resource
+// does not exist, such as one that has already been deleted.
+func isNotFound(details *core.DetailedResponse, err error) bool {
+	return icibmcloud.IsAPIErrorReason(icibmcloud.NewAPIError(details, err), icibmcloud.ReasonNotFound)
+}
+
+// isResourceInUse returns whether a deletion was rejected because other
+// resources still depend on the resource. The deletion succeeds once they are
+// deleted, so it is retried rather than reported as a failure. Any other
+// conflict is reported.
+func isResourceInUse(details *core.DetailedResponse, err error) bool {
+	return icibmcloud.IsAPIErrorReason(icibmcloud.NewAPIError(details, err), icibmcloud.ReasonResourceInUse)
+}
+
 // aggregateError is a utility function that takes a slice of errors and an
 // optional pending argument, and returns an error or nil
 func aggregateError(errs []error, pending ...int) error {
@@ -431,11 +458,19 @@ func aggregateError(errs []error, pending ...int) error {
 		return err
 	}
 	if len(pending) > 0 && pending[0] > 0 {
-		return errors.Errorf("%d items pending", pending[0])
+		return pendingItemsError(pending[0])
 	}
 	return nil
 }
 
+// pendingItemsError reports the number of items of a resource type that are
+// still being deleted.
+type pendingItemsError int
+
+func (e pendingItemsError) Error() string {
+	return fmt.Sprintf("%d items pending", int(e))
+}
+
 // pendingItemTracker tracks a set of pending item names for a given type of resource
 type pendingItemTracker struct {
 	pendingItems map[string]cloudResources
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -16,23 +15,43 @@ func (o *ClusterUninstaller) listImages() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListImagesOptions()
-	resources, _, err := o.vpcSvc.ListImagesWithContext(ctx, options)
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list images")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListImagesOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetVisibility(vpcv1.ListImagesOptionsVisibilityPrivateConst)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, image := range resources.Images {
-		if strings.Contains(*image.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *image.ID,
-				name:     *image.Name,
-				status:   *image.Status,
-				typeName: imageTypeName,
-				id:       *image.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListImagesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list images")
 		}
+
+		for _, image := range resources.Images {
+			if strings.Contains(*image.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *image.ID,
+					name:     *image.Name,
+					status:   *image.Status,
+					typeName: imageTypeName,
+					id:       *image.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -51,14 +70,21 @@ func (o *ClusterUninstaller) deleteImage(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteImageOptions(item.id)
 	details, err := o.vpcSvc.DeleteImageWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted image %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// The image cannot be deleted while instances are still being
+		// provisioned from it, retry once they are gone.
+		o.Logger.Debugf("Image %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete image %s", item.name)
 	}
 
@@ -88,7 +114,7 @@ func (o *ClusterUninstaller) destroyImages() error {
 	}
 
 	if items = o.getPendingItems(imageTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,13 +1,13 @@
 package ibmcloud
 
 import (
-	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
 )
 
 const (
@@ -24,24 +24,37 @@ func (o *ClusterUninstaller) listInstances() (cloudResources, error) {
 	if len(o.UserProvidedVPC) > 0 {
 		options.SetVPCName(o.UserProvidedVPC)
 	} else {
-		options.SetVPCName(fmt.Sprintf("%s-vpc", o.InfraID))
-	}
-	resources, _, err := o.vpcSvc.ListInstancesWithContext(ctx, options)
-	if err != nil {
-		return nil, err
+		options.SetVPCName(names.VPCName(o.InfraID))
 	}
+	options.SetLimit(100)
 
 	result := []cloudResource{}
-	for _, instance := range resources.Instances {
-		if strings.Contains(*instance.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *instance.ID,
-				name:     *instance.Name,
-				status:   *instance.Status,
-				typeName: "instance",
-				id:       *instance.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, instance := range resources.Instances {
+			if strings.Contains(*instance.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *instance.ID,
+					name:     *instance.Name,
+					status:   *instance.Status,
+					typeName: "instance",
+					id:       *instance.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
 		}
+		options.SetStart(*start)
 	}
 	return cloudResources{}.insert(result...), nil
 }
@@ -69,7 +82,7 @@ func (o *ClusterUninstaller) stopInstances() error {
 	}
 
 	if items = o.getPendingItems(instanceActionTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -116,14 +129,14 @@ func (o *ClusterUninstaller) deleteInstance(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteInstanceOptions(item.id)
 	details, err := o.vpcSvc.DeleteInstanceWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted instance %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete instance %q", item.name)
 	}
 
@@ -153,7 +166,7 @@ func (o *ClusterUninstaller) destroyInstances() error {
 	}
 
 	if items = o.getPendingItems(instanceTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
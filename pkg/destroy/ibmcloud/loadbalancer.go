@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -17,22 +16,35 @@ func (o *ClusterUninstaller) listLoadBalancers() (cloudResources, error) {
 	defer cancel()
 
 	options := o.vpcSvc.NewListLoadBalancersOptions()
-	resources, _, err := o.vpcSvc.ListLoadBalancersWithContext(ctx, options)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list load balancers")
-	}
+	options.SetLimit(100)
 
 	result := []cloudResource{}
-	for _, loadbalancer := range resources.LoadBalancers {
-		if strings.Contains(*loadbalancer.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *loadbalancer.ID,
-				name:     *loadbalancer.Name,
-				status:   *loadbalancer.ProvisioningStatus,
-				typeName: loadBalancerTypeName,
-				id:       *loadbalancer.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListLoadBalancersWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list load balancers")
+		}
+
+		for _, loadbalancer := range resources.LoadBalancers {
+			if strings.Contains(*loadbalancer.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *loadbalancer.ID,
+					name:     *loadbalancer.Name,
+					status:   *loadbalancer.ProvisioningStatus,
+					typeName: loadBalancerTypeName,
+					id:       *loadbalancer.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
 		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -51,14 +63,20 @@ func (o *ClusterUninstaller) deleteLoadBalancer(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteLoadBalancerOptions(item.id)
 	details, err := o.vpcSvc.DeleteLoadBalancerWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone.
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted load balancer %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the load balancer are still being deleted
+		o.Logger.Debugf("Load balancer %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete load balancer %s", item.name)
 	}
 
@@ -88,7 +106,7 @@ func (o *ClusterUninstaller) destroyLoadBalancers() error {
 	}
 
 	if items = o.getPendingItems(loadBalancerTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
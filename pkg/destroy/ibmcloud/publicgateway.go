@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -16,23 +15,42 @@ func (o *ClusterUninstaller) listPublicGateways() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListPublicGatewaysOptions()
-	resources, _, err := o.vpcSvc.ListPublicGatewaysWithContext(ctx, options)
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list public gateways")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListPublicGatewaysOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, publicGateway := range resources.PublicGateways {
-		if strings.Contains(*publicGateway.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *publicGateway.ID,
-				name:     *publicGateway.Name,
-				status:   *publicGateway.Status,
-				typeName: publicGatewayTypeName,
-				id:       *publicGateway.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListPublicGatewaysWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list public gateways")
 		}
+
+		for _, publicGateway := range resources.PublicGateways {
+			if strings.Contains(*publicGateway.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *publicGateway.ID,
+					name:     *publicGateway.Name,
+					status:   *publicGateway.Status,
+					typeName: publicGatewayTypeName,
+					id:       *publicGateway.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -51,14 +69,20 @@ func (o *ClusterUninstaller) deletePublicGateway(item cloudResource) error {
 	options := o.vpcSvc.NewDeletePublicGatewayOptions(item.id)
 	details, err := o.vpcSvc.DeletePublicGatewayWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted public gateway %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the public gateway are still being deleted
+		o.Logger.Debugf("Public gateway %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete public gateway %s", item.name)
 	}
 
@@ -94,7 +118,7 @@ func (o *ClusterUninstaller) destroyPublicGateways() error {
 	}
 
 	if items = o.getPendingItems(publicGatewayTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,8 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
-
 	"github.com/pkg/errors"
 )
 
@@ -45,14 +43,20 @@ func (o *ClusterUninstaller) deleteResourceGroup(item cloudResource) error {
 	options := o.managementSvc.NewDeleteResourceGroupOptions(item.id)
 	details, err := o.managementSvc.DeleteResourceGroupWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted resource group %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the resource group are still being deleted
+		o.Logger.Debugf("Resource group %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete resource group %s", item.name)
 	}
 
@@ -88,7 +92,7 @@ func (o *ClusterUninstaller) destroyResourceGroups() error {
 	}
 
 	if items = o.getPendingItems(resourceGroupTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"reflect"
 	"strings"
 
@@ -20,23 +19,42 @@ func (o *ClusterUninstaller) listSecurityGroups() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListSecurityGroupsOptions()
-	resources, _, err := o.vpcSvc.ListSecurityGroupsWithContext(ctx, options)
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "Failed to list security groups")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListSecurityGroupsOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, securityGroup := range resources.SecurityGroups {
-		if strings.Contains(*securityGroup.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *securityGroup.ID,
-				name:     *securityGroup.Name,
-				status:   "",
-				typeName: securityGroupTypeName,
-				id:       *securityGroup.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListSecurityGroupsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to list security groups")
 		}
+
+		for _, securityGroup := range resources.SecurityGroups {
+			if strings.Contains(*securityGroup.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *securityGroup.ID,
+					name:     *securityGroup.Name,
+					status:   "",
+					typeName: securityGroupTypeName,
+					id:       *securityGroup.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -126,20 +144,26 @@ func (o *ClusterUninstaller) deleteSecurityGroup(item cloudResource) error {
 	}
 
 	if rules = o.getPendingItems(securityGroupRuleTypeName); len(rules) > 0 {
-		return errors.Errorf("%d items pending", len(rules))
+		return pendingItemsError(len(rules))
 	}
 
 	options := o.vpcSvc.NewDeleteSecurityGroupOptions(item.id)
 	details, err := o.vpcSvc.DeleteSecurityGroupWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted security group %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the security group are still being deleted
+		o.Logger.Debugf("Security group %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete security group %s", item.name)
 	}
 
@@ -153,14 +177,14 @@ func (o *ClusterUninstaller) deleteSecurityGroupRule(item cloudResource, securit
 
 	options := o.vpcSvc.NewDeleteSecurityGroupRuleOptions(securityGroupID, item.id)
 	details, err := o.vpcSvc.DeleteSecurityGroupRuleWithContext(ctx, options)
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted security group rule %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete security group rule %s", item.name)
 	}
 
@@ -196,7 +220,7 @@ func (o *ClusterUninstaller) destroySecurityGroups() error {
 	}
 
 	if items = o.getPendingItems(securityGroupTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -16,23 +15,42 @@ func (o *ClusterUninstaller) listSubnets() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListSubnetsOptions()
-	resources, _, err := o.vpcSvc.ListSubnetsWithContext(ctx, options)
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list subnets")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListSubnetsOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, subnet := range resources.Subnets {
-		if strings.Contains(*subnet.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *subnet.ID,
-				name:     *subnet.Name,
-				status:   *subnet.Status,
-				typeName: subnetTypeName,
-				id:       *subnet.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListSubnetsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list subnets")
 		}
+
+		for _, subnet := range resources.Subnets {
+			if strings.Contains(*subnet.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *subnet.ID,
+					name:     *subnet.Name,
+					status:   *subnet.Status,
+					typeName: subnetTypeName,
+					id:       *subnet.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -51,14 +69,20 @@ func (o *ClusterUninstaller) deleteSubnet(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteSubnetOptions(item.id)
 	details, err := o.vpcSvc.DeleteSubnetWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted subnet %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
+	if isResourceInUse(details, err) {
+		// Resources depending on the subnet are still being deleted
+		o.Logger.Debugf("Subnet %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
 		return errors.Wrapf(err, "Failed to delete subnet %s", item.name)
 	}
 
@@ -94,7 +118,7 @@ func (o *ClusterUninstaller) destroySubnets() error {
 	}
 
 	if items = o.getPendingItems(subnetTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package ibmcloud
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -16,23 +15,42 @@ func (o *ClusterUninstaller) listVPCs() (cloudResources, error) {
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	options := o.vpcSvc.NewListVpcsOptions()
-	resources, _, err := o.vpcSvc.ListVpcsWithContext(ctx, options)
+	resourceGroupID, err := o.ResourceGroupID()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list vpcs")
+		return nil, err
 	}
 
+	options := o.vpcSvc.NewListVpcsOptions()
+	options.SetResourceGroupID(resourceGroupID)
+	options.SetLimit(100)
+
 	result := []cloudResource{}
-	for _, vpc := range resources.Vpcs {
-		if strings.Contains(*vpc.Name, o.InfraID) {
-			result = append(result, cloudResource{
-				key:      *vpc.ID,
-				name:     *vpc.Name,
-				status:   *vpc.Status,
-				typeName: vpcTypeName,
-				id:       *vpc.ID,
-			})
+	for {
+		resources, _, err := o.vpcSvc.ListVpcsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list vpcs")
 		}
+
+		for _, vpc := range resources.Vpcs {
+			if strings.Contains(*vpc.Name, o.InfraID) {
+				result = append(result, cloudResource{
+					key:      *vpc.ID,
+					name:     *vpc.Name,
+					status:   *vpc.Status,
+					typeName: vpcTypeName,
+					id:       *vpc.ID,
+				})
+			}
+		}
+
+		if resources.Next == nil {
+			break
+		}
+		start, err := resources.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(*start)
 	}
 
 	return cloudResources{}.insert(result...), nil
@@ -51,15 +69,21 @@ func (o *ClusterUninstaller) deleteVPC(item cloudResource) error {
 	options := o.vpcSvc.NewDeleteVPCOptions(item.id)
 	details, err := o.vpcSvc.DeleteVPCWithContext(ctx, options)
 
-	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
+	if isNotFound(details, err) {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
 		o.Logger.Infof("Deleted VPC %q", item.name)
 		return nil
 	}
 
-	if err != nil && details != nil && details.StatusCode != http.StatusNotFound {
-		return errors.Wrapf(err, "Failed to delete VOC %s", item.name)
+	if isResourceInUse(details, err) {
+		// Resources depending on the VPC are still being deleted
+		o.Logger.Debugf("VPC %q is still in use, retrying", item.name)
+		return nil
+	}
+
+	if err != nil && details != nil && !isNotFound(details, err) {
+		return errors.Wrapf(err, "Failed to delete VPC %s", item.name)
 	}
 
 	return nil
@@ -93,7 +117,7 @@ func (o *ClusterUninstaller) destroyVPCs() error {
 	}
 
 	if items = o.getPendingItems(vpcTypeName); len(items) > 0 {
-		return errors.Errorf("%d items pending", len(items))
+		return pendingItemsError(len(items))
 	}
 	return nil
 }
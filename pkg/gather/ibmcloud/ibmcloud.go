@@ -0,0 +1,241 @@
+package ibmcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/gather"
+	"github.com/openshift/installer/pkg/gather/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// Gather holds options for resources we want to gather.
+type Gather struct {
+	logger          logrus.FieldLogger
+	region          string
+	infraID         string
+	clusterName     string
+	baseDomain      string
+	cisInstanceCRN  string
+	dnsInstanceID   string
+	vpcName         string
+	serialLogBundle string
+	bootstrap       string
+	masters         []string
+	directory       string
+}
+
+// instanceDiagnostics is the content written to the bundle for each
+// instance. The VPC serial console is interactive only and keeps no log
+// history, so the instance status and initialization are gathered instead.
+type instanceDiagnostics struct {
+	Instance       *vpcv1.Instance               `json:"instance"`
+	Initialization *vpcv1.InstanceInitialization `json:"initialization,omitempty"`
+}
+
+// New returns an IBM Cloud Gather from ClusterMetadata.
+func New(logger logrus.FieldLogger, serialLogBundle string, bootstrap string, masters []string, metadata *types.ClusterMetadata) (providers.Gather, error) {
+	vpcName := metadata.ClusterPlatformMetadata.IBMCloud.VPC
+	if vpcName == "" {
+		vpcName = names.VPCName(metadata.InfraID)
+	}
+
+	return &Gather{
+		logger:          logger,
+		region:          metadata.ClusterPlatformMetadata.IBMCloud.Region,
+		infraID:         metadata.InfraID,
+		clusterName:     metadata.ClusterName,
+		baseDomain:      metadata.ClusterPlatformMetadata.IBMCloud.BaseDomain,
+		cisInstanceCRN:  metadata.ClusterPlatformMetadata.IBMCloud.CISInstanceCRN,
+		dnsInstanceID:   metadata.ClusterPlatformMetadata.IBMCloud.DNSInstanceID,
+		vpcName:         vpcName,
+		serialLogBundle: serialLogBundle,
+		bootstrap:       bootstrap,
+		masters:         masters,
+		directory:       filepath.Dir(serialLogBundle),
+	}, nil
+}
+
+// Run is the entrypoint to start the gather process.
+func (g *Gather) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	vpcSvc, err := g.vpcService(ctx)
+	if err != nil {
+		return err
+	}
+
+	serialLogBundleDir := strings.TrimSuffix(filepath.Base(g.serialLogBundle), ".tar.gz")
+	filePathDir := filepath.Join(g.directory, serialLogBundleDir)
+	err = os.MkdirAll(filePathDir, 0755)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+
+	var errs []error
+	var files []string
+	filePath, err := g.writeResources(ctx, vpcSvc, filePathDir)
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to gather cluster resources"))
+	} else {
+		files = append(files, filePath)
+	}
+
+	instances, err := g.findInstances(ctx, vpcSvc, g.isControlPlaneInstance)
+	if err != nil {
+		errs = append(errs, err)
+	} else if len(instances) == 0 {
+		g.logger.Infoln("Skipping instance diagnostics gathering: no instances found")
+	}
+	for _, instance := range instances {
+		filePath, err := g.writeDiagnostics(ctx, vpcSvc, instance, filePathDir)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			files = append(files, filePath)
+		}
+	}
+
+	if len(files) > 0 {
+		err := gather.CreateArchive(files, g.serialLogBundle)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to create archive"))
+		}
+	}
+
+	if err := gather.DeleteArchiveDirectory(filePathDir); err != nil {
+		// Note: cleanup is best effort, it shouldn't fail the gather
+		g.logger.Debugf("Failed to remove archive directory: %v", err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// vpcService returns a VPC client for the region of the cluster.
+func (g *Gather) vpcService(ctx context.Context) (*vpcv1.VpcV1, error) {
+	authenticator, err := icibmcloud.NewIamAuthenticator(os.Getenv("IC_API_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	vpcSvc, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		Authenticator: authenticator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	vpcSvc.Service.SetUserAgent(userAgent())
+
+	region, detailedResponse, err := vpcSvc.GetRegionWithContext(ctx, vpcSvc.NewGetRegionOptions(g.region))
+	if err != nil {
+		return nil, icibmcloud.NewAPIError(detailedResponse, err)
+	}
+	if err := vpcSvc.SetServiceURL(fmt.Sprintf("%s/v1", *region.Endpoint)); err != nil {
+		return nil, err
+	}
+	return vpcSvc, nil
+}
+
+// userAgent returns the user agent of the IBM Cloud clients.
+func userAgent() string {
+	return fmt.Sprintf("OpenShift/4.x Gather/%s", version.Raw)
+}
+
+// findInstances returns the instances in the VPC of the cluster whose names
+// match.
+func (g *Gather) findInstances(ctx context.Context, vpcSvc *vpcv1.VpcV1, match func(name string) bool) ([]vpcv1.Instance, error) {
+	var instances []vpcv1.Instance
+
+	options := vpcSvc.NewListInstancesOptions()
+	options.SetVPCName(g.vpcName)
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing instances")
+		}
+
+		for _, instance := range resources.Instances {
+			if instance.Name != nil && match(*instance.Name) {
+				instances = append(instances, instance)
+			}
+		}
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// page is a page of an IBM Cloud list call.
+type page interface {
+	GetNextStart() (*string, error)
+}
+
+// listPages calls list with the start of each page, beginning with a nil
+// start for the first page, until the last page has been listed.
+func listPages(list func(start *string) (page, error)) error {
+	var start *string
+	for {
+		resources, err := list(start)
+		if err != nil {
+			return err
+		}
+		start, err = resources.GetNextStart()
+		if err != nil {
+			return errors.Wrap(err, "getting the next page")
+		}
+		if start == nil {
+			return nil
+		}
+	}
+}
+
+// isControlPlaneInstance returns whether the instance name is that of the
+// bootstrap or a control plane machine of the cluster.
+func (g *Gather) isControlPlaneInstance(name string) bool {
+	return strings.HasPrefix(name, names.BootstrapName(g.infraID)) ||
+		strings.HasPrefix(name, fmt.Sprintf("%s-%s-", g.infraID, names.ControlPlaneRole))
+}
+
+func (g *Gather) writeDiagnostics(ctx context.Context, vpcSvc *vpcv1.VpcV1, instance vpcv1.Instance, filePathDir string) (string, error) {
+	logger := g.logger.WithField("Instance", *instance.Name)
+
+	diagnostics := instanceDiagnostics{Instance: &instance}
+	initialization, detailedResponse, err := vpcSvc.GetInstanceInitializationWithContext(ctx, vpcSvc.NewGetInstanceInitializationOptions(*instance.ID))
+	if err != nil {
+		// The status of the instance is still useful without it.
+		logger.Debugf("Failed to get instance initialization: %v", icibmcloud.NewAPIError(detailedResponse, err))
+	} else {
+		diagnostics.Initialization = initialization
+	}
+
+	content, err := json.MarshalIndent(diagnostics, "", "  ")
+	if err != nil {
+		return "", errors.Wrapf(err, "marshaling diagnostics for instance %s", *instance.Name)
+	}
+
+	filePath := filepath.Join(filePathDir, fmt.Sprintf("%s-status.json", *instance.Name))
+	if err := os.WriteFile(filePath, content, 0600); err != nil {
+		return "", err
+	}
+	logger.Debugf("Wrote instance diagnostics to %s", filePath)
+	return filePath, nil
+}
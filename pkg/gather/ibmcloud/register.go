@@ -0,0 +1,7 @@
+package ibmcloud
+
+import "github.com/openshift/installer/pkg/gather/providers"
+
+func init() {
+	providers.Registry["ibmcloud"] = New
+}
@@ -0,0 +1,452 @@
+package ibmcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/networking-go-sdk/dnssvcsv1"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/pkg/errors"
+
+	icibmcloud "github.com/openshift/installer/pkg/asset/installconfig/ibmcloud"
+	"github.com/openshift/installer/pkg/types/ibmcloud/names"
+)
+
+// clusterResources is the state of the resources of the cluster written to
+// the bundle for support cases. Resources shared with other clusters in a
+// user-provided VPC are excluded by the infrastructure ID prefix of their
+// names, except for the VPC, subnets and security groups themselves.
+type clusterResources struct {
+	VPC            *vpcv1.VPC                              `json:"vpc,omitempty"`
+	Subnets        []vpcv1.Subnet                          `json:"subnets"`
+	SecurityGroups []vpcv1.SecurityGroup                   `json:"securityGroups"`
+	LoadBalancers  []loadBalancerState                     `json:"loadBalancers"`
+	Instances      []vpcv1.Instance                        `json:"instances"`
+	Images         []vpcv1.Image                           `json:"images"`
+	COSInstances   []resourcecontrollerv2.ResourceInstance `json:"cosInstances"`
+
+	// Only one of the DNS record lists is set, depending on whether the base
+	// domain is served by CIS or by DNS Services.
+	CISDNSRecords      []dnsrecordsv1.DnsrecordDetails `json:"cisDNSRecords,omitempty"`
+	DNSServicesRecords []dnssvcsv1.ResourceRecord      `json:"dnsServicesRecords,omitempty"`
+}
+
+// cosResourceID is the catalog ID of the cloud-object-storage service.
+const cosResourceID = "dff97f5c-bc5e-4455-b470-411c3edbe49c"
+
+// loadBalancerState is a load balancer with its pools and their members.
+type loadBalancerState struct {
+	LoadBalancer vpcv1.LoadBalancer      `json:"loadBalancer"`
+	Pools        []loadBalancerPoolState `json:"pools"`
+}
+
+// loadBalancerPoolState is a load balancer pool with its members.
+type loadBalancerPoolState struct {
+	Pool    vpcv1.LoadBalancerPool         `json:"pool"`
+	Members []vpcv1.LoadBalancerPoolMember `json:"members"`
+}
+
+// writeResources writes the state of the cluster resources to
+// <infraID>-resources.json in filePathDir.
+func (g *Gather) writeResources(ctx context.Context, vpcSvc *vpcv1.VpcV1, filePathDir string) (string, error) {
+	resources, err := g.collectResources(ctx, vpcSvc)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling cluster resources")
+	}
+
+	filePath := filepath.Join(filePathDir, fmt.Sprintf("%s-resources.json", g.infraID))
+	if err := os.WriteFile(filePath, content, 0600); err != nil {
+		return "", err
+	}
+	g.logger.Debugf("Wrote cluster resources to %s", filePath)
+	return filePath, nil
+}
+
+func (g *Gather) collectResources(ctx context.Context, vpcSvc *vpcv1.VpcV1) (*clusterResources, error) {
+	resources := &clusterResources{}
+
+	vpc, err := g.findVPC(ctx, vpcSvc)
+	if err != nil {
+		return nil, err
+	}
+	resources.VPC = vpc
+
+	if vpc != nil {
+		if resources.Subnets, err = g.listSubnets(ctx, vpcSvc, *vpc.ID); err != nil {
+			return nil, err
+		}
+		if resources.SecurityGroups, err = g.listSecurityGroups(ctx, vpcSvc, *vpc.ID); err != nil {
+			return nil, err
+		}
+		if resources.Instances, err = g.findInstances(ctx, vpcSvc, g.ownedByCluster); err != nil {
+			return nil, err
+		}
+	}
+	if resources.LoadBalancers, err = g.listLoadBalancers(ctx, vpcSvc); err != nil {
+		return nil, err
+	}
+	if resources.Images, err = g.listImages(ctx, vpcSvc); err != nil {
+		return nil, err
+	}
+	if resources.COSInstances, err = g.listCOSInstances(ctx); err != nil {
+		return nil, err
+	}
+	switch {
+	case g.cisInstanceCRN != "":
+		if resources.CISDNSRecords, err = g.listCISDNSRecords(ctx); err != nil {
+			return nil, err
+		}
+	case g.dnsInstanceID != "":
+		if resources.DNSServicesRecords, err = g.listDNSServicesRecords(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+// ownedByCluster returns whether the resource name carries the
+// infrastructure ID of the cluster.
+func (g *Gather) ownedByCluster(name string) bool {
+	return strings.HasPrefix(name, fmt.Sprintf("%s-", g.infraID))
+}
+
+// isClusterDNSRecord returns whether the DNS record name is within the
+// cluster domain.
+func (g *Gather) isClusterDNSRecord(name *string) bool {
+	return name != nil && strings.HasSuffix(*name, fmt.Sprintf(".%s.%s", g.clusterName, g.baseDomain))
+}
+
+func (g *Gather) findVPC(ctx context.Context, vpcSvc *vpcv1.VpcV1) (*vpcv1.VPC, error) {
+	var found *vpcv1.VPC
+
+	options := vpcSvc.NewListVpcsOptions()
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListVpcsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing vpcs")
+		}
+
+		for i, vpc := range resources.Vpcs {
+			if found == nil && vpc.Name != nil && *vpc.Name == g.vpcName {
+				found = &resources.Vpcs[i]
+			}
+		}
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		g.logger.Debugf("VPC %s not found", g.vpcName)
+	}
+	return found, nil
+}
+
+func (g *Gather) listSubnets(ctx context.Context, vpcSvc *vpcv1.VpcV1, vpcID string) ([]vpcv1.Subnet, error) {
+	var subnets []vpcv1.Subnet
+
+	options := vpcSvc.NewListSubnetsOptions()
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListSubnetsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing subnets")
+		}
+
+		for _, subnet := range resources.Subnets {
+			if subnet.VPC != nil && subnet.VPC.ID != nil && *subnet.VPC.ID == vpcID {
+				subnets = append(subnets, subnet)
+			}
+		}
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subnets, nil
+}
+
+func (g *Gather) listSecurityGroups(ctx context.Context, vpcSvc *vpcv1.VpcV1, vpcID string) ([]vpcv1.SecurityGroup, error) {
+	var securityGroups []vpcv1.SecurityGroup
+
+	options := vpcSvc.NewListSecurityGroupsOptions()
+	options.SetVPCID(vpcID)
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListSecurityGroupsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing security groups")
+		}
+
+		securityGroups = append(securityGroups, resources.SecurityGroups...)
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return securityGroups, nil
+}
+
+func (g *Gather) listLoadBalancers(ctx context.Context, vpcSvc *vpcv1.VpcV1) ([]loadBalancerState, error) {
+	var loadBalancers []loadBalancerState
+
+	options := vpcSvc.NewListLoadBalancersOptions()
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListLoadBalancersWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing load balancers")
+		}
+
+		for _, lb := range resources.LoadBalancers {
+			if lb.Name == nil || !g.ownedByCluster(*lb.Name) {
+				continue
+			}
+			pools, err := g.listLoadBalancerPools(ctx, vpcSvc, *lb.ID)
+			if err != nil {
+				return nil, err
+			}
+			loadBalancers = append(loadBalancers, loadBalancerState{LoadBalancer: lb, Pools: pools})
+		}
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadBalancers, nil
+}
+
+func (g *Gather) listLoadBalancerPools(ctx context.Context, vpcSvc *vpcv1.VpcV1, lbID string) ([]loadBalancerPoolState, error) {
+	resources, detailedResponse, err := vpcSvc.ListLoadBalancerPoolsWithContext(ctx, vpcSvc.NewListLoadBalancerPoolsOptions(lbID))
+	if err != nil {
+		return nil, errors.Wrapf(icibmcloud.NewAPIError(detailedResponse, err), "listing pools of load balancer %s", lbID)
+	}
+
+	var pools []loadBalancerPoolState
+	for _, pool := range resources.Pools {
+		members, detailedResponse, err := vpcSvc.ListLoadBalancerPoolMembersWithContext(ctx, vpcSvc.NewListLoadBalancerPoolMembersOptions(lbID, *pool.ID))
+		if err != nil {
+			return nil, errors.Wrapf(icibmcloud.NewAPIError(detailedResponse, err), "listing members of load balancer pool %s", *pool.ID)
+		}
+		pools = append(pools, loadBalancerPoolState{Pool: pool, Members: members.Members})
+	}
+	return pools, nil
+}
+
+func (g *Gather) listImages(ctx context.Context, vpcSvc *vpcv1.VpcV1) ([]vpcv1.Image, error) {
+	var images []vpcv1.Image
+
+	options := vpcSvc.NewListImagesOptions()
+	options.SetName(names.ImageName(g.infraID))
+	options.SetVisibility(vpcv1.ListImagesOptionsVisibilityPrivateConst)
+	options.SetLimit(100)
+	err := listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := vpcSvc.ListImagesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing images")
+		}
+
+		images = append(images, resources.Images...)
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// listCOSInstances returns the COS instances created for the cluster by
+// the installer and the cluster-image-registry-operator.
+func (g *Gather) listCOSInstances(ctx context.Context) ([]resourcecontrollerv2.ResourceInstance, error) {
+	authenticator, err := icibmcloud.NewIamAuthenticator(os.Getenv("IC_API_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	controllerSvc, err := resourcecontrollerv2.NewResourceControllerV2(&resourcecontrollerv2.ResourceControllerV2Options{
+		Authenticator: authenticator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	controllerSvc.Service.SetUserAgent(userAgent())
+
+	var instances []resourcecontrollerv2.ResourceInstance
+
+	options := controllerSvc.NewListResourceInstancesOptions()
+	options.SetResourceID(cosResourceID)
+	options.SetType("service_instance")
+	options.SetLimit(100)
+	err = listPages(func(start *string) (page, error) {
+		if start != nil {
+			options.SetStart(*start)
+		}
+		resources, detailedResponse, err := controllerSvc.ListResourceInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing cos instances")
+		}
+
+		for _, instance := range resources.Resources {
+			if instance.Name != nil && (*instance.Name == names.COSInstanceName(g.infraID) ||
+				*instance.Name == names.ImageRegistryCOSInstanceName(g.infraID)) {
+				instances = append(instances, instance)
+			}
+		}
+		return resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// listCISDNSRecords returns the records of the cluster domain in the CIS
+// zone of the base domain.
+func (g *Gather) listCISDNSRecords(ctx context.Context) ([]dnsrecordsv1.DnsrecordDetails, error) {
+	authenticator, err := icibmcloud.NewIamAuthenticator(os.Getenv("IC_API_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	zonesSvc, err := zonesv1.NewZonesV1(&zonesv1.ZonesV1Options{
+		Authenticator: authenticator,
+		Crn:           core.StringPtr(g.cisInstanceCRN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	zonesSvc.Service.SetUserAgent(userAgent())
+
+	zones, detailedResponse, err := zonesSvc.ListZonesWithContext(ctx, zonesSvc.NewListZonesOptions())
+	if err != nil {
+		return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing cis zones")
+	}
+	zoneID := ""
+	for _, zone := range zones.Result {
+		if zone.Name != nil && strings.Contains(g.baseDomain, *zone.Name) {
+			zoneID = *zone.ID
+		}
+	}
+	if zoneID == "" {
+		g.logger.Debugf("CIS zone of base domain %s not found", g.baseDomain)
+		return nil, nil
+	}
+
+	dnsRecordsSvc, err := dnsrecordsv1.NewDnsRecordsV1(&dnsrecordsv1.DnsRecordsV1Options{
+		Authenticator:  authenticator,
+		Crn:            core.StringPtr(g.cisInstanceCRN),
+		ZoneIdentifier: core.StringPtr(zoneID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	dnsRecordsSvc.Service.SetUserAgent(userAgent())
+
+	var records []dnsrecordsv1.DnsrecordDetails
+
+	options := dnsRecordsSvc.NewListAllDnsRecordsOptions()
+	options.SetPerPage(100)
+	for pageNumber := int64(1); ; pageNumber++ {
+		options.SetPage(pageNumber)
+		resources, detailedResponse, err := dnsRecordsSvc.ListAllDnsRecordsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing dns records")
+		}
+
+		for _, record := range resources.Result {
+			if g.isClusterDNSRecord(record.Name) {
+				records = append(records, record)
+			}
+		}
+
+		info := resources.ResultInfo
+		if info == nil || info.Page == nil || info.PerPage == nil || info.TotalCount == nil ||
+			*info.Page**info.PerPage >= *info.TotalCount {
+			break
+		}
+	}
+	return records, nil
+}
+
+// listDNSServicesRecords returns the records of the cluster domain in the
+// DNS Services zone of the base domain.
+func (g *Gather) listDNSServicesRecords(ctx context.Context) ([]dnssvcsv1.ResourceRecord, error) {
+	authenticator, err := icibmcloud.NewIamAuthenticator(os.Getenv("IC_API_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	dnsServicesSvc, err := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+		Authenticator: authenticator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dnsServicesSvc.Service.SetUserAgent(userAgent())
+
+	zones, detailedResponse, err := dnsServicesSvc.ListDnszonesWithContext(ctx, dnsServicesSvc.NewListDnszonesOptions(g.dnsInstanceID))
+	if err != nil {
+		return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing dns zones")
+	}
+	zoneID := ""
+	for _, zone := range zones.Dnszones {
+		if zone.Name != nil && *zone.Name == g.baseDomain {
+			zoneID = *zone.ID
+			break
+		}
+	}
+	if zoneID == "" {
+		g.logger.Debugf("DNS zone of base domain %s not found", g.baseDomain)
+		return nil, nil
+	}
+
+	var records []dnssvcsv1.ResourceRecord
+
+	options := dnsServicesSvc.NewListResourceRecordsOptions(g.dnsInstanceID, zoneID)
+	options.SetLimit(100)
+	for offset := int64(0); ; {
+		options.SetOffset(offset)
+		resources, detailedResponse, err := dnsServicesSvc.ListResourceRecordsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(icibmcloud.NewAPIError(detailedResponse, err), "listing dns records")
+		}
+
+		for _, record := range resources.ResourceRecords {
+			if g.isClusterDNSRecord(record.Name) {
+				records = append(records, record)
+			}
+		}
+
+		offset += int64(len(resources.ResourceRecords))
+		if len(resources.ResourceRecords) == 0 || resources.TotalCount == nil || offset >= *resources.TotalCount {
+			break
+		}
+	}
+	return records, nil
+}
@@ -47,6 +47,7 @@ type config struct {
 // TFVarsSources contains the parameters to be converted into Terraform variables
 type TFVarsSources struct {
 	Auth                     Auth
+	BootstrapInstanceType    string
 	CISInstanceCRN           string
 	DNSInstanceID            string
 	ImageURL                 string
@@ -92,9 +93,14 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		}
 	}
 
+	bootstrapInstanceType := sources.BootstrapInstanceType
+	if bootstrapInstanceType == "" {
+		bootstrapInstanceType = masterConfig.Profile
+	}
+
 	cfg := &config{
 		Auth:                     sources.Auth,
-		BootstrapInstanceType:    masterConfig.Profile,
+		BootstrapInstanceType:    bootstrapInstanceType,
 		CISInstanceCRN:           sources.CISInstanceCRN,
 		DNSInstanceID:            sources.DNSInstanceID,
 		ImageFilePath:            cachedImage,
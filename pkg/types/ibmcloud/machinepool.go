@@ -1,5 +1,19 @@
 package ibmcloud
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultMTU is the MTU of the machine network interfaces when none is
+	// configured.
+	DefaultMTU uint32 = 1500
+	// MinMTU and MaxMTU bound the MTU of the machine network interfaces. VPC
+	// networks, and every VPC instance profile, support jumbo frames up to 9000.
+	MinMTU uint32 = 1280
+	MaxMTU uint32 = 9000
+)
+
 // MachinePool stores the configuration for a machine pool installed on IBM Cloud.
 type MachinePool struct {
 	// InstanceType is the VSI machine profile.
@@ -16,6 +30,36 @@ type MachinePool struct {
 	// DedicatedHosts is the configuration for the machine's dedicated host and profile.
 	// +optional
 	DedicatedHosts []DedicatedHost `json:"dedicatedHosts,omitempty"`
+
+	// MTU is the maximum transmission unit of the network interfaces of the
+	// machines, for example 9000 to use jumbo frames within the VPC. The
+	// cluster network MTU is derived from it, so every machine pool must use
+	// the same MTU. Defaults to 1500.
+	// +optional
+	MTU uint32 `json:"mtu,omitempty"`
+
+	// NodeLabels are additional labels applied to the nodes created for the
+	// machine pool, for example to select the pool in a machine autoscaler or
+	// in workload scheduling. Only applied to compute machine pools.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are taints applied to the nodes created for the machine pool.
+	// Only applied to compute machine pools.
+	// +optional
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+
+	// MachineLabels are additional labels applied to the Machine and
+	// MachineSet objects of the machine pool, for example for policy engines or
+	// cost attribution. Keys in the machine.openshift.io/ namespace are
+	// reserved.
+	// +optional
+	MachineLabels map[string]string `json:"machineLabels,omitempty"`
+
+	// MachineAnnotations are additional annotations applied to the Machine and
+	// MachineSet objects of the machine pool.
+	// +optional
+	MachineAnnotations map[string]string `json:"machineAnnotations,omitempty"`
 }
 
 // BootVolume stores the configuration for an individual machine's boot volume.
@@ -66,4 +110,33 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if len(required.DedicatedHosts) > 0 {
 		a.DedicatedHosts = required.DedicatedHosts
 	}
+
+	if required.MTU != 0 {
+		a.MTU = required.MTU
+	}
+
+	if len(required.NodeLabels) > 0 {
+		a.NodeLabels = required.NodeLabels
+	}
+
+	if len(required.NodeTaints) > 0 {
+		a.NodeTaints = required.NodeTaints
+	}
+
+	if len(required.MachineLabels) > 0 {
+		a.MachineLabels = required.MachineLabels
+	}
+
+	if len(required.MachineAnnotations) > 0 {
+		a.MachineAnnotations = required.MachineAnnotations
+	}
+}
+
+// MTUOrDefault returns the MTU of the machine pool, or DefaultMTU if none is
+// configured.
+func (a *MachinePool) MTUOrDefault() uint32 {
+	if a.MTU != 0 {
+		return a.MTU
+	}
+	return DefaultMTU
 }
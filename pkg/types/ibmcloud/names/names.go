@@ -0,0 +1,161 @@
+// Package names builds the names of the IBM Cloud resources the installer
+// creates for a cluster, so every consumer agrees on them.
+package names
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// MaxNameLength is the maximum length of a VPC resource name.
+// https://cloud.ibm.com/docs/vpc?topic=vpc-vpc-naming-conventions
+const MaxNameLength = 63
+
+// clusterOwnedTagPrefix and clusterOwnedTagSuffix surround the infrastructure
+// ID in the tag of the resources created for a cluster.
+const (
+	clusterOwnedTagPrefix = "kubernetes-io-cluster-"
+	clusterOwnedTagSuffix = ":owned"
+)
+
+// hashLength is the number of hex characters of the hash that replaces the
+// end of a truncated name.
+const hashLength = 8
+
+// Role is the machine role a role-specific resource is created for.
+type Role string
+
+const (
+	// ControlPlaneRole is the role of the control plane machines.
+	ControlPlaneRole Role = "master"
+	// ComputeRole is the role of the compute machines.
+	ComputeRole Role = "worker"
+)
+
+// SecurityGroup is the kind of a cluster security group.
+type SecurityGroup string
+
+const (
+	// ClusterWideSecurityGroup allows traffic between all cluster nodes.
+	ClusterWideSecurityGroup SecurityGroup = "cluster-wide"
+	// OpenShiftNetworkSecurityGroup allows the OpenShift SDN and node traffic.
+	OpenShiftNetworkSecurityGroup SecurityGroup = "openshift-net"
+	// ControlPlaneSecurityGroup allows external traffic to the control plane.
+	ControlPlaneSecurityGroup SecurityGroup = "control-plane"
+	// ControlPlaneInternalSecurityGroup allows internal traffic to the
+	// control plane.
+	ControlPlaneInternalSecurityGroup SecurityGroup = "cp-internal"
+)
+
+// VPCName returns the name of the VPC created for the cluster.
+func VPCName(infraID string) string {
+	return fmt.Sprintf("%s-vpc", infraID)
+}
+
+// ImageName returns the name of the RHCOS custom image.
+func ImageName(infraID string) string {
+	return fmt.Sprintf("%s-rhcos", infraID)
+}
+
+// COSInstanceName returns the name of the Cloud Object Storage instance
+// holding the bootstrap ignition and RHCOS image.
+func COSInstanceName(infraID string) string {
+	return fmt.Sprintf("%s-cos", infraID)
+}
+
+// ImageRegistryCOSInstanceName returns the name of the Cloud Object Storage
+// instance created by the image registry operator.
+func ImageRegistryCOSInstanceName(infraID string) string {
+	return fmt.Sprintf("%s-image-registry", infraID)
+}
+
+// BootstrapName returns the name of the bootstrap instance.
+func BootstrapName(infraID string) string {
+	return fmt.Sprintf("%s-bootstrap", infraID)
+}
+
+// MachineName returns the name of a machine of the pool.
+func MachineName(infraID string, pool string, index int64) string {
+	return fmt.Sprintf("%s-%s-%d", infraID, pool, index)
+}
+
+// SubnetName returns the name of the subnet created for the role in the zone,
+// truncated to MaxNameLength.
+func SubnetName(infraID string, role Role, zone string) (string, error) {
+	return roleZoneName(infraID, "subnet", role, zone)
+}
+
+// SubnetNames returns the names of the subnets created for the role, keyed by
+// zone, so a pool's machines share one set of generated names.
+func SubnetNames(infraID string, role Role, zones []string) (map[string]string, error) {
+	subnets := make(map[string]string, len(zones))
+	for _, zone := range zones {
+		if _, ok := subnets[zone]; ok {
+			continue
+		}
+		name, err := SubnetName(infraID, role, zone)
+		if err != nil {
+			return nil, err
+		}
+		subnets[zone] = name
+	}
+	return subnets, nil
+}
+
+// DedicatedHostName returns the name of the dedicated host created for the
+// role in the zone, truncated to MaxNameLength.
+func DedicatedHostName(infraID string, role Role, zone string) (string, error) {
+	return roleZoneName(infraID, "dhost", role, zone)
+}
+
+// APILoadBalancerName returns the name of the public or private load balancer
+// of the API.
+func APILoadBalancerName(infraID string, public bool) string {
+	if public {
+		return fmt.Sprintf("%s-kubernetes-api-public", infraID)
+	}
+	return fmt.Sprintf("%s-kubernetes-api-private", infraID)
+}
+
+// ClusterOwnedTag returns the tag of the resources created for the cluster.
+func ClusterOwnedTag(infraID string) string {
+	return fmt.Sprintf("%s%s%s", clusterOwnedTagPrefix, infraID, clusterOwnedTagSuffix)
+}
+
+// OwningCluster returns the infrastructure ID of the cluster a tag returned by
+// ClusterOwnedTag marks as the owner, and whether the tag is such a tag.
+func OwningCluster(tag string) (string, bool) {
+	if !strings.HasPrefix(tag, clusterOwnedTagPrefix) || !strings.HasSuffix(tag, clusterOwnedTagSuffix) {
+		return "", false
+	}
+	infraID := strings.TrimSuffix(strings.TrimPrefix(tag, clusterOwnedTagPrefix), clusterOwnedTagSuffix)
+	return infraID, infraID != ""
+}
+
+// SecurityGroupName returns the name of the security group of the kind.
+func SecurityGroupName(infraID string, kind SecurityGroup) string {
+	return fmt.Sprintf("%s-sg-%s", infraID, kind)
+}
+
+// Truncate shortens a name longer than MaxNameLength, replacing its end with a
+// hash of the full name so that distinct long names stay distinct. Names
+// within the limit are returned unchanged.
+func Truncate(name string) string {
+	if len(name) <= MaxNameLength {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:hashLength]
+	return fmt.Sprintf("%s-%s", name[:MaxNameLength-hashLength-1], hash)
+}
+
+func roleZoneName(infraID string, resource string, role Role, zone string) (string, error) {
+	switch role {
+	case ControlPlaneRole:
+		return Truncate(fmt.Sprintf("%s-%s-control-plane-%s", infraID, resource, zone)), nil
+	case ComputeRole:
+		return Truncate(fmt.Sprintf("%s-%s-compute-%s", infraID, resource, zone)), nil
+	default:
+		return "", fmt.Errorf("invalid machine role %v", role)
+	}
+}
@@ -0,0 +1,84 @@
+package names
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubnetName(t *testing.T) {
+	cases := []struct {
+		name     string
+		role     Role
+		expected string
+		err      string
+	}{
+		{
+			name:     "control plane",
+			role:     ControlPlaneRole,
+			expected: "infra-id-subnet-control-plane-us-south-1",
+		},
+		{
+			name:     "compute",
+			role:     ComputeRole,
+			expected: "infra-id-subnet-compute-us-south-1",
+		},
+		{
+			name: "invalid role",
+			role: "bootstrap",
+			err:  "invalid machine role bootstrap",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := SubnetName("infra-id", tc.role, "us-south-1")
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestSubnetNames(t *testing.T) {
+	subnets, err := SubnetNames("infra-id", ComputeRole, []string{"us-south-1", "us-south-2", "us-south-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"us-south-1": "infra-id-subnet-compute-us-south-1",
+		"us-south-2": "infra-id-subnet-compute-us-south-2",
+	}, subnets)
+
+	_, err = SubnetNames("infra-id", "bootstrap", []string{"us-south-1"})
+	assert.EqualError(t, err, "invalid machine role bootstrap")
+}
+
+func TestAPILoadBalancerName(t *testing.T) {
+	assert.Equal(t, "infra-id-kubernetes-api-public", APILoadBalancerName("infra-id", true))
+	assert.Equal(t, "infra-id-kubernetes-api-private", APILoadBalancerName("infra-id", false))
+}
+
+func TestOwningCluster(t *testing.T) {
+	infraID, ok := OwningCluster(ClusterOwnedTag("infra-id"))
+	assert.True(t, ok)
+	assert.Equal(t, "infra-id", infraID)
+
+	for _, tag := range []string{"env:prod", "kubernetes-io-cluster-:owned", "kubernetes-io-cluster-infra-id"} {
+		_, ok := OwningCluster(tag)
+		assert.False(t, ok, tag)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	short := "infra-id-subnet-compute-us-south-1"
+	assert.Equal(t, short, Truncate(short))
+
+	long := strings.Repeat("a", MaxNameLength) + "-us-south-1"
+	truncated := Truncate(long)
+	assert.Len(t, truncated, MaxNameLength)
+	assert.Regexp(t, `^[a-z]([-a-z0-9]*[a-z0-9])?$`, truncated)
+	assert.Equal(t, truncated, Truncate(long))
+	assert.NotEqual(t, truncated, Truncate(strings.Repeat("a", MaxNameLength)+"-us-south-2"))
+}
@@ -1,11 +1,30 @@
 package ibmcloud
 
+// UserProvisionedDNS indicates whether the DNS records of the cluster are
+// managed by the user instead of in IBM Cloud.
+type UserProvisionedDNS string
+
+const (
+	// UserProvisionedDNSEnabled skips creating the cluster DNS records in IBM
+	// Cloud Internet Services or DNS Services.
+	UserProvisionedDNSEnabled UserProvisionedDNS = "Enabled"
+	// UserProvisionedDNSDisabled creates the cluster DNS records in IBM Cloud.
+	UserProvisionedDNSDisabled UserProvisionedDNS = "Disabled"
+)
+
 // Platform stores all the global configuration that all machinesets use.
 type Platform struct {
 	// Region specifies the IBM Cloud region where the cluster will be
 	// created.
 	Region string `json:"region"`
 
+	// AccountID is the ID of the IBM Cloud account the cluster is expected to
+	// be created in. When set, the installer fails early if the API key
+	// belongs to a different account, such as another account of an
+	// enterprise.
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+
 	// ResourceGroupName is the name of an already existing resource group where the
 	// cluster should be installed. If empty, a new resource group will be created
 	// for the cluster.
@@ -23,15 +42,51 @@ type Platform struct {
 	// +optional
 	VPCName string `json:"vpcName,omitempty"`
 
-	// ControlPlaneSubnets are the names of already existing subnets where the
-	// cluster control plane nodes should be created.
+	// ControlPlaneSubnets are the already existing subnets where the cluster
+	// control plane nodes should be created.
 	// +optional
-	ControlPlaneSubnets []string `json:"controlPlaneSubnets,omitempty"`
+	ControlPlaneSubnets []Subnet `json:"controlPlaneSubnets,omitempty"`
 
-	// ComputeSubnets are the names of already existing subnets where the cluster
+	// ComputeSubnets are the already existing subnets where the cluster
 	// compute nodes should be created.
 	// +optional
-	ComputeSubnets []string `json:"computeSubnets,omitempty"`
+	ComputeSubnets []Subnet `json:"computeSubnets,omitempty"`
+
+	// SecurityGroups are the names of existing security groups in the VPC to
+	// attach to the cluster machines in place of the installer-created ones.
+	// The installer-created security groups are still created, and the
+	// bootstrap machine keeps using them. Requires an existing VPC.
+	// +optional
+	SecurityGroups *SecurityGroups `json:"securityGroups,omitempty"`
+
+	// UserProvisionedDNS indicates whether the DNS records of the cluster are
+	// provided by the user in a DNS solution outside of IBM Cloud. When
+	// "Enabled", the installer does not look up the base domain zone or create
+	// the api, api-int and *.apps records, and the ingress operator does not
+	// manage DNS records. Valid values are "Enabled" and "Disabled". Defaults
+	// to "Disabled".
+	// +kubebuilder:validation:Enum="";Enabled;Disabled
+	// +optional
+	UserProvisionedDNS UserProvisionedDNS `json:"userProvisionedDNS,omitempty"`
+
+	// NTPServers are the hostnames or IP addresses of the NTP servers the
+	// cluster machines synchronize their clocks with. When unset, the
+	// machines keep the chrony configuration of the operating system.
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+
+	// BootstrapInstanceType is the VSI machine profile of the bootstrap
+	// machine, for example a smaller profile than the control plane machines
+	// to reduce cost. Defaults to the control plane profile.
+	// +optional
+	BootstrapInstanceType string `json:"bootstrapInstanceType,omitempty"`
+
+	// ServiceEndpoints are custom endpoints used by the installer in place of
+	// the default endpoints of IBM Cloud services, for example the private
+	// endpoints of the services. There must be at most one endpoint per
+	// service.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 
 	// DefaultMachinePlatform is the default configuration used when installing
 	// on IBM Cloud for machine pools which do not define their own platform
@@ -48,6 +103,15 @@ func (p *Platform) ClusterResourceGroupName(infraID string) string {
 	return infraID
 }
 
+// GetSubnetResourceGroupName returns the name of the resource group of the
+// user provided subnet.
+func (p *Platform) GetSubnetResourceGroupName(subnet Subnet) string {
+	if len(subnet.ResourceGroupName) > 0 {
+		return subnet.ResourceGroupName
+	}
+	return p.NetworkResourceGroupName
+}
+
 // GetVPCName returns the user provided name of the VPC for the cluster.
 func (p *Platform) GetVPCName() string {
 	if len(p.VPCName) > 0 {
@@ -55,3 +119,20 @@ func (p *Platform) GetVPCName() string {
 	}
 	return ""
 }
+
+// IsUserProvisionedDNS returns whether the DNS records of the cluster are
+// provided by the user.
+func (p *Platform) IsUserProvisionedDNS() bool {
+	return p.UserProvisionedDNS == UserProvisionedDNSEnabled
+}
+
+// ServiceEndpointURL returns the custom endpoint of the service, or an empty
+// string when the default endpoint is used.
+func (p *Platform) ServiceEndpointURL(name ServiceName) string {
+	for _, endpoint := range p.ServiceEndpoints {
+		if endpoint.Name == name {
+			return endpoint.URL
+		}
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package ibmcloud
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,3 +33,26 @@ func TestGetVPCName(t *testing.T) {
 		assert.Equal(t, tc.expectedResult, platform.GetVPCName())
 	}
 }
+
+func TestGetSubnetResourceGroupName(t *testing.T) {
+	platform := Platform{NetworkResourceGroupName: "network-rg"}
+	assert.Equal(t, "network-rg", platform.GetSubnetResourceGroupName(Subnet{Name: "subnet"}))
+	assert.Equal(t, "subnet-rg", platform.GetSubnetResourceGroupName(Subnet{Name: "subnet", ResourceGroupName: "subnet-rg"}))
+}
+
+func TestServiceEndpointURL(t *testing.T) {
+	platform := Platform{ServiceEndpoints: []ServiceEndpoint{{Name: ServiceNameIAM, URL: "https://private.iam.cloud.ibm.com"}}}
+	assert.Equal(t, "https://private.iam.cloud.ibm.com", platform.ServiceEndpointURL(ServiceNameIAM))
+	assert.Equal(t, "", platform.ServiceEndpointURL(ServiceNameVPC))
+}
+
+func TestSubnetUnmarshalJSON(t *testing.T) {
+	var subnets []Subnet
+	err := json.Unmarshal([]byte(`["subnet-1", {"name": "subnet-2", "resourceGroupName": "subnet-rg"}]`), &subnets)
+	assert.NoError(t, err)
+	assert.Equal(t, []Subnet{
+		{Name: "subnet-1"},
+		{Name: "subnet-2", ResourceGroupName: "subnet-rg"},
+	}, subnets)
+	assert.Equal(t, []string{"subnet-1", "subnet-2"}, SubnetNames(subnets))
+}
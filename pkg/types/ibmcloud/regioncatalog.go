@@ -0,0 +1,17 @@
+package ibmcloud
+
+//go:generate go run ../../../hack/ibmcloud-region-catalog.go -output ./regioncatalog_generated.go
+
+// RegionCatalogEntry holds the VPC zones and instance profiles of an IBM Cloud
+// region, as known when the catalog was generated.
+type RegionCatalogEntry struct {
+	Zones    []string
+	Profiles []string
+}
+
+// CatalogRegion returns the catalog entry of the region, used when the IBM
+// Cloud APIs cannot be reached.
+func CatalogRegion(region string) (RegionCatalogEntry, bool) {
+	entry, ok := RegionCatalog[region]
+	return entry, ok
+}
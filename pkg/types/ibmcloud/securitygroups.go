@@ -0,0 +1,22 @@
+package ibmcloud
+
+// SecurityGroups stores the names of existing security groups attached to the
+// cluster machines and service load balancers. They replace the security
+// groups the machine specs reference by default; the installer-created
+// security groups are still created.
+type SecurityGroups struct {
+	// ControlPlane are the security groups attached to the control plane
+	// machines.
+	ControlPlane []string `json:"controlPlane"`
+
+	// Compute are the security groups attached to the compute machines.
+	Compute []string `json:"compute"`
+
+	// LoadBalancer are the security groups the cloud controller manager
+	// attaches to the load balancers it creates for services. They must
+	// allow the service listener ports and the node ports of the compute
+	// machines. When unset, the cloud controller manager uses the default
+	// security group of the VPC.
+	// +optional
+	LoadBalancer []string `json:"loadBalancer,omitempty"`
+}
@@ -0,0 +1,45 @@
+package ibmcloud
+
+// ServiceName is the name of an IBM Cloud service the installer calls.
+type ServiceName string
+
+const (
+	// ServiceNameIAM is the Identity and Access Management service.
+	ServiceNameIAM ServiceName = "IAM"
+	// ServiceNameVPC is the Virtual Private Cloud service.
+	ServiceNameVPC ServiceName = "VPC"
+	// ServiceNameResourceController is the Resource Controller service.
+	ServiceNameResourceController ServiceName = "ResourceController"
+	// ServiceNameResourceManager is the Resource Manager service.
+	ServiceNameResourceManager ServiceName = "ResourceManager"
+	// ServiceNameDNSServices is the DNS Services service.
+	ServiceNameDNSServices ServiceName = "DNSServices"
+	// ServiceNameCIS is the Cloud Internet Services service.
+	ServiceNameCIS ServiceName = "CIS"
+	// ServiceNameGlobalTagging is the Global Tagging service.
+	ServiceNameGlobalTagging ServiceName = "GlobalTagging"
+)
+
+// ServiceEndpoint is a custom URL used in place of the default endpoint of an
+// IBM Cloud service, for example a private endpoint.
+type ServiceEndpoint struct {
+	// Name is the name of the IBM Cloud service.
+	Name ServiceName `json:"name"`
+
+	// URL is the custom endpoint of the service. It must use https.
+	URL string `json:"url"`
+}
+
+// ServiceNames are the names of the services which can be given a custom
+// endpoint.
+func ServiceNames() []ServiceName {
+	return []ServiceName{
+		ServiceNameIAM,
+		ServiceNameVPC,
+		ServiceNameResourceController,
+		ServiceNameResourceManager,
+		ServiceNameDNSServices,
+		ServiceNameCIS,
+		ServiceNameGlobalTagging,
+	}
+}
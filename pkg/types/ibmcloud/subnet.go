@@ -0,0 +1,39 @@
+package ibmcloud
+
+import "encoding/json"
+
+// Subnet is an already existing subnet the cluster machines are created in.
+type Subnet struct {
+	// Name is the name of the subnet.
+	Name string `json:"name"`
+
+	// ResourceGroupName is the name of the resource group of the subnet, when
+	// it differs from NetworkResourceGroupName. Defaults to
+	// NetworkResourceGroupName.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+}
+
+// UnmarshalJSON also accepts the name of the subnet on its own, so subnet
+// lists written before subnets could set their resource group keep working.
+func (s *Subnet) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = Subnet{Name: name}
+		return nil
+	}
+	type subnet Subnet
+	return json.Unmarshal(data, (*subnet)(s))
+}
+
+// SubnetNames returns the names of the subnets.
+func SubnetNames(subnets []Subnet) []string {
+	if subnets == nil {
+		return nil
+	}
+	names := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		names = append(names, subnet.Name)
+	}
+	return names
+}
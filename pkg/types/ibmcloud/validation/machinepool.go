@@ -5,11 +5,17 @@ import (
 	"strings"
 
 	"github.com/IBM-Cloud/bluemix-go/crn"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
+// machineAPILabelPrefix is the prefix of the labels the installer sets on
+// Machine and MachineSet objects.
+const machineAPILabelPrefix = "machine.openshift.io/"
+
 // ValidateMachinePool validates the MachinePool.
 func ValidateMachinePool(platform *ibmcloud.Platform, mp *ibmcloud.MachinePool, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -30,6 +36,67 @@ func ValidateMachinePool(platform *ibmcloud.Platform, mp *ibmcloud.MachinePool,
 	if mp.BootVolume != nil {
 		allErrs = append(allErrs, validateBootVolume(mp.BootVolume, path.Child("bootVolume"))...)
 	}
+
+	if mp.MTU != 0 && (mp.MTU < ibmcloud.MinMTU || mp.MTU > ibmcloud.MaxMTU) {
+		allErrs = append(allErrs, field.Invalid(path.Child("mtu"), mp.MTU, fmt.Sprintf("mtu must be between %d and %d", ibmcloud.MinMTU, ibmcloud.MaxMTU)))
+	}
+
+	for key, value := range mp.NodeLabels {
+		allErrs = append(allErrs, validateNodeLabel(key, value, path.Child("nodeLabels"))...)
+	}
+
+	for i, taint := range mp.NodeTaints {
+		allErrs = append(allErrs, validateNodeTaint(taint, path.Child("nodeTaints").Index(i))...)
+	}
+
+	for key, value := range mp.MachineLabels {
+		if strings.HasPrefix(key, machineAPILabelPrefix) {
+			allErrs = append(allErrs, field.Invalid(path.Child("machineLabels"), key, fmt.Sprintf("label keys with the %q prefix are reserved", machineAPILabelPrefix)))
+			continue
+		}
+		allErrs = append(allErrs, validateNodeLabel(key, value, path.Child("machineLabels"))...)
+	}
+
+	for key := range mp.MachineAnnotations {
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path.Child("machineAnnotations"), key, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateNodeLabel(key string, value string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsQualifiedName(key) {
+		allErrs = append(allErrs, field.Invalid(path, key, msg))
+	}
+	for _, msg := range validation.IsValidLabelValue(value) {
+		allErrs = append(allErrs, field.Invalid(path, value, msg))
+	}
+	return allErrs
+}
+
+var validTaintEffects = map[corev1.TaintEffect]struct{}{
+	corev1.TaintEffectNoSchedule:       {},
+	corev1.TaintEffectPreferNoSchedule: {},
+	corev1.TaintEffectNoExecute:        {},
+}
+
+func validateNodeTaint(taint corev1.Taint, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsQualifiedName(taint.Key) {
+		allErrs = append(allErrs, field.Invalid(path.Child("key"), taint.Key, msg))
+	}
+	for _, msg := range validation.IsValidLabelValue(taint.Value) {
+		allErrs = append(allErrs, field.Invalid(path.Child("value"), taint.Value, msg))
+	}
+	if _, ok := validTaintEffects[taint.Effect]; !ok {
+		allErrs = append(allErrs, field.NotSupported(path.Child("effect"), taint.Effect, []string{
+			string(corev1.TaintEffectNoSchedule),
+			string(corev1.TaintEffectPreferNoSchedule),
+			string(corev1.TaintEffectNoExecute),
+		}))
+	}
 	return allErrs
 }
 
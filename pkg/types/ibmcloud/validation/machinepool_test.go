@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/ibmcloud"
@@ -167,6 +168,91 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid node labels and taints",
+			machinepool: &ibmcloud.MachinePool{
+				NodeLabels: map[string]string{
+					"node-role.kubernetes.io/infra": "",
+					"example.com/pool":              "gpu",
+				},
+				NodeTaints: []corev1.Taint{
+					{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "example.com/gpu", Value: "true", Effect: corev1.TaintEffectNoExecute},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid node label",
+			machinepool: &ibmcloud.MachinePool{
+				NodeLabels: map[string]string{
+					"example.com/pool": "not a valid value",
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid machine labels and annotations",
+			machinepool: &ibmcloud.MachinePool{
+				MachineLabels: map[string]string{
+					"example.com/cost-center": "1234",
+				},
+				MachineAnnotations: map[string]string{
+					"example.com/owner": "team a",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid reserved machine label",
+			machinepool: &ibmcloud.MachinePool{
+				MachineLabels: map[string]string{
+					"machine.openshift.io/cluster-api-cluster": "other",
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid machine annotation key",
+			machinepool: &ibmcloud.MachinePool{
+				MachineAnnotations: map[string]string{
+					"-invalid": "",
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid node taint key",
+			machinepool: &ibmcloud.MachinePool{
+				NodeTaints: []corev1.Taint{
+					{Key: "-invalid", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid node taint effect",
+			machinepool: &ibmcloud.MachinePool{
+				NodeTaints: []corev1.Taint{
+					{Key: "example.com/gpu", Effect: "Evict"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid jumbo frame mtu",
+			machinepool: &ibmcloud.MachinePool{
+				MTU: 9000,
+			},
+			valid: true,
+		},
+		{
+			name: "invalid mtu",
+			machinepool: &ibmcloud.MachinePool{
+				MTU: 9216,
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
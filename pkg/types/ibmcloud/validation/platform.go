@@ -1,11 +1,28 @@
 package validation
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
+const (
+	// maxDNSNameLength is the maximum length of a DNS name, without the
+	// trailing dot.
+	maxDNSNameLength = 253
+
+	// longestRecordPrefix is the prefix of the longest DNS record of a
+	// cluster, the wildcard record of the default ingress.
+	longestRecordPrefix = "*.apps."
+)
+
 var (
 	// Regions is a map of IBM Cloud regions where VPCs are supported.
 	// The key of the map is the short name of the region. The value
@@ -35,7 +52,7 @@ var (
 )
 
 // ValidatePlatform checks that the specified platform is valid.
-func ValidatePlatform(p *ibmcloud.Platform, fldPath *field.Path) field.ErrorList {
+func ValidatePlatform(p *ibmcloud.Platform, fldPath *field.Path, ic *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if p.Region == "" {
@@ -55,8 +72,161 @@ func ValidatePlatform(p *ibmcloud.Platform, fldPath *field.Path) field.ErrorList
 		allErrs = append(allErrs, field.Required(fldPath.Child("vpcName"), "must provide a VPC name when supplying subnets"))
 	}
 
+	allErrs = append(allErrs, validateSubnets(p.ControlPlaneSubnets, fldPath.Child("controlPlaneSubnets"))...)
+	allErrs = append(allErrs, validateSubnets(p.ComputeSubnets, fldPath.Child("computeSubnets"))...)
+
+	if p.SecurityGroups != nil {
+		allErrs = append(allErrs, validateSecurityGroups(p, fldPath.Child("securityGroups"))...)
+	}
+
+	allErrs = append(allErrs, validateMachinePoolMTUs(p, ic)...)
+	allErrs = append(allErrs, validateClusterDomainLength(ic)...)
+
+	if len(p.NTPServers) > 0 {
+		allErrs = append(allErrs, validateNTPServers(p.NTPServers, fldPath.Child("ntpServers"))...)
+	}
+
+	switch p.UserProvisionedDNS {
+	case "", ibmcloud.UserProvisionedDNSDisabled, ibmcloud.UserProvisionedDNSEnabled:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("userProvisionedDNS"), p.UserProvisionedDNS, []string{
+			string(ibmcloud.UserProvisionedDNSEnabled),
+			string(ibmcloud.UserProvisionedDNSDisabled),
+		}))
+	}
+
+	if len(p.ServiceEndpoints) > 0 {
+		allErrs = append(allErrs, validateServiceEndpoints(p.ServiceEndpoints, fldPath.Child("serviceEndpoints"))...)
+	}
+
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p, p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
 	return allErrs
 }
+
+func validateServiceEndpoints(endpoints []ibmcloud.ServiceEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	serviceNames := sets.NewString()
+	for _, name := range ibmcloud.ServiceNames() {
+		serviceNames.Insert(string(name))
+	}
+	seen := sets.NewString()
+	for i, endpoint := range endpoints {
+		endpointPath := fldPath.Index(i)
+		switch {
+		case !serviceNames.Has(string(endpoint.Name)):
+			allErrs = append(allErrs, field.NotSupported(endpointPath.Child("name"), endpoint.Name, serviceNames.List()))
+		case seen.Has(string(endpoint.Name)):
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("name"), endpoint.Name))
+		}
+		seen.Insert(string(endpoint.Name))
+
+		if endpoint.URL == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("url"), "url must be specified"))
+		} else if u, err := url.Parse(endpoint.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("url"), endpoint.URL, "must be an https URL"))
+		}
+	}
+	return allErrs
+}
+
+func validateSubnets(subnets []ibmcloud.Subnet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, subnet := range subnets {
+		if subnet.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "subnet name must be specified"))
+		}
+	}
+	return allErrs
+}
+
+func validateSecurityGroups(p *ibmcloud.Platform, fldPath *field.Path) field.ErrorList {
+	if p.VPCName == "" {
+		return field.ErrorList{field.Invalid(fldPath, p.SecurityGroups, "securityGroups may only be provided with an existing VPC")}
+	}
+
+	allErrs := field.ErrorList{}
+	for _, groups := range []struct {
+		name     string
+		names    []string
+		optional bool
+	}{
+		{name: "controlPlane", names: p.SecurityGroups.ControlPlane},
+		{name: "compute", names: p.SecurityGroups.Compute},
+		{name: "loadBalancer", names: p.SecurityGroups.LoadBalancer, optional: true},
+	} {
+		groupsPath := fldPath.Child(groups.name)
+		if len(groups.names) == 0 && !groups.optional {
+			allErrs = append(allErrs, field.Required(groupsPath, "at least one security group must be provided"))
+			continue
+		}
+		seen := sets.NewString()
+		for i, name := range groups.names {
+			if seen.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(groupsPath.Index(i), name))
+			}
+			seen.Insert(name)
+		}
+	}
+	return allErrs
+}
+
+// validateClusterDomainLength checks that the DNS records of the cluster fit
+// in a DNS name, which would otherwise only fail when the records are created
+// in Cloud Internet Services or DNS Services.
+func validateClusterDomainLength(ic *types.InstallConfig) field.ErrorList {
+	if ic.ObjectMeta.Name == "" || ic.BaseDomain == "" {
+		return nil
+	}
+	if record := longestRecordPrefix + ic.ClusterDomain(); len(record) > maxDNSNameLength {
+		budget := maxDNSNameLength - len(longestRecordPrefix) - len(".")
+		return field.ErrorList{field.Invalid(field.NewPath("baseDomain"), ic.BaseDomain, fmt.Sprintf("metadata.name and baseDomain must be at most %d characters combined, so the DNS record %s fits in %d characters", budget, record, maxDNSNameLength))}
+	}
+	return nil
+}
+
+// validateMachinePoolMTUs checks that every machine pool uses the same MTU,
+// since the cluster network MTU is derived from it.
+func validateMachinePoolMTUs(p *ibmcloud.Platform, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	poolMTU := func(pool *ibmcloud.MachinePool) uint32 {
+		mpool := ibmcloud.MachinePool{}
+		mpool.Set(p.DefaultMachinePlatform)
+		mpool.Set(pool)
+		return mpool.MTUOrDefault()
+	}
+
+	var controlPlaneMTU uint32
+	if ic.ControlPlane != nil {
+		controlPlaneMTU = poolMTU(ic.ControlPlane.Platform.IBMCloud)
+	} else {
+		controlPlaneMTU = poolMTU(nil)
+	}
+	for idx, compute := range ic.Compute {
+		if mtu := poolMTU(compute.Platform.IBMCloud); mtu != controlPlaneMTU {
+			fldPath := field.NewPath("compute").Index(idx).Child("platform").Child("ibmcloud").Child("mtu")
+			allErrs = append(allErrs, field.Invalid(fldPath, mtu, fmt.Sprintf("mtu must match the control plane mtu %d", controlPlaneMTU)))
+		}
+	}
+	return allErrs
+}
+
+func validateNTPServers(servers []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, server := range servers {
+		switch {
+		case seen.Has(server):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), server))
+		case net.ParseIP(server) != nil:
+		default:
+			if errs := utilvalidation.IsDNS1123Subdomain(server); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), server, "must be an IP address or hostname"))
+			}
+		}
+		seen.Insert(server)
+	}
+	return allErrs
+}
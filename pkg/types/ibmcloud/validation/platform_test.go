@@ -1,11 +1,14 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/ibmcloud"
 )
 
@@ -25,9 +28,10 @@ func validMachinePool() *ibmcloud.MachinePool {
 
 func TestValidatePlatform(t *testing.T) {
 	cases := []struct {
-		name     string
-		platform *ibmcloud.Platform
-		valid    bool
+		name          string
+		platform      *ibmcloud.Platform
+		installConfig *types.InstallConfig
+		valid         bool
 	}{
 		{
 			name:     "minimal",
@@ -66,8 +70,8 @@ func TestValidatePlatform(t *testing.T) {
 			platform: func() *ibmcloud.Platform {
 				p := validMinimalPlatform()
 				p.VPCName = "valid-vpc-subnets"
-				p.ControlPlaneSubnets = []string{"cp-1", "cp-2", "cp-3"}
-				p.ComputeSubnets = []string{"comp-1", "comp-2"}
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}, {Name: "cp-2"}, {Name: "cp-3"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "comp-1"}, {Name: "comp-2"}}
 				return p
 			}(),
 			valid: true,
@@ -77,7 +81,7 @@ func TestValidatePlatform(t *testing.T) {
 			platform: func() *ibmcloud.Platform {
 				p := validMinimalPlatform()
 				p.VPCName = "missing-cp-subnet"
-				p.ComputeSubnets = []string{"comp-1", "comp-2"}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "comp-1"}, {Name: "comp-2"}}
 				return p
 			}(),
 			valid: false,
@@ -87,7 +91,7 @@ func TestValidatePlatform(t *testing.T) {
 			platform: func() *ibmcloud.Platform {
 				p := validMinimalPlatform()
 				p.VPCName = "missing-comp-subnet"
-				p.ControlPlaneSubnets = []string{"cp-1", "cp-2"}
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}, {Name: "cp-2"}}
 				return p
 			}(),
 			valid: false,
@@ -96,8 +100,250 @@ func TestValidatePlatform(t *testing.T) {
 			name: "subnets without vpc",
 			platform: func() *ibmcloud.Platform {
 				p := validMinimalPlatform()
-				p.ControlPlaneSubnets = []string{"cp-1"}
-				p.ComputeSubnets = []string{"comp-1"}
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "comp-1"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid security groups",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1"}}
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp"},
+					Compute:      []string{"sg-compute"},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid security groups without vpc",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp"},
+					Compute:      []string{"sg-compute"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid security groups missing compute",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1"}}
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid duplicate security groups",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1"}}
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp", "sg-cp"},
+					Compute:      []string{"sg-compute"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid load balancer security groups",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1"}}
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp"},
+					Compute:      []string{"sg-compute"},
+					LoadBalancer: []string{"sg-lb"},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid duplicate load balancer security groups",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1"}}
+				p.SecurityGroups = &ibmcloud.SecurityGroups{
+					ControlPlane: []string{"sg-cp"},
+					Compute:      []string{"sg-compute"},
+					LoadBalancer: []string{"sg-lb", "sg-lb"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid jumbo frame mtu for every machine pool",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.DefaultMachinePlatform = &ibmcloud.MachinePool{MTU: 9000}
+				return p
+			}(),
+			installConfig: &types.InstallConfig{
+				ControlPlane: &types.MachinePool{Name: "master"},
+				Compute:      []types.MachinePool{{Name: "worker"}},
+			},
+			valid: true,
+		},
+		{
+			name:     "invalid compute mtu not matching control plane",
+			platform: validMinimalPlatform(),
+			installConfig: &types.InstallConfig{
+				ControlPlane: &types.MachinePool{Name: "master"},
+				Compute: []types.MachinePool{{
+					Name: "worker",
+					Platform: types.MachinePoolPlatform{
+						IBMCloud: &ibmcloud.MachinePool{MTU: 9000},
+					},
+				}},
+			},
+			valid: false,
+		},
+		{
+			name:     "valid cluster domain length",
+			platform: validMinimalPlatform(),
+			installConfig: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				BaseDomain: strings.Repeat("a", 245-len("cluster")),
+			},
+			valid: true,
+		},
+		{
+			name:     "invalid cluster domain length",
+			platform: validMinimalPlatform(),
+			installConfig: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				BaseDomain: strings.Repeat("a", 246-len("cluster")),
+			},
+			valid: false,
+		},
+		{
+			name: "valid subnet resource groups",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1", ResourceGroupName: "cp-rg"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{Name: "compute-1", ResourceGroupName: "compute-rg"}, {Name: "compute-2"}}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid subnet without name",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.VPCName = "valid-vpc"
+				p.ControlPlaneSubnets = []ibmcloud.Subnet{{Name: "cp-1"}}
+				p.ComputeSubnets = []ibmcloud.Subnet{{ResourceGroupName: "compute-rg"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid ntp servers",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.NTPServers = []string{"ntp.example.com", "10.0.0.1"}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid ntp server",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.NTPServers = []string{"ntp_example.com"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid duplicate ntp servers",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.NTPServers = []string{"ntp.example.com", "ntp.example.com"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid user-provisioned dns",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.UserProvisionedDNS = ibmcloud.UserProvisionedDNSEnabled
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid user-provisioned dns",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.UserProvisionedDNS = "enabled"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid service endpoints",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []ibmcloud.ServiceEndpoint{
+					{Name: ibmcloud.ServiceNameIAM, URL: "https://private.iam.cloud.ibm.com"},
+					{Name: ibmcloud.ServiceNameVPC, URL: "https://us-south.private.iaas.cloud.ibm.com/v1"},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid service endpoint name",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []ibmcloud.ServiceEndpoint{{Name: "COS", URL: "https://s3.direct.us-south.cloud-object-storage.appdomain.cloud"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid duplicate service endpoints",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []ibmcloud.ServiceEndpoint{
+					{Name: ibmcloud.ServiceNameIAM, URL: "https://private.iam.cloud.ibm.com"},
+					{Name: ibmcloud.ServiceNameIAM, URL: "https://iam.cloud.ibm.com"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid service endpoint url",
+			platform: func() *ibmcloud.Platform {
+				p := validMinimalPlatform()
+				p.ServiceEndpoints = []ibmcloud.ServiceEndpoint{{Name: ibmcloud.ServiceNameIAM, URL: "http://private.iam.cloud.ibm.com"}}
 				return p
 			}(),
 			valid: false,
@@ -105,7 +351,11 @@ func TestValidatePlatform(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidatePlatform(tc.platform, field.NewPath("test-path")).ToAggregate()
+			ic := tc.installConfig
+			if ic == nil {
+				ic = &types.InstallConfig{}
+			}
+			err := ValidatePlatform(tc.platform, field.NewPath("test-path"), ic).ToAggregate()
 			if tc.valid {
 				assert.NoError(t, err)
 			} else {
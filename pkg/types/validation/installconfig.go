@@ -89,7 +89,7 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 		}
 	}
 	nameErr := validate.ClusterName(c.ObjectMeta.Name)
-	if c.Platform.GCP != nil || c.Platform.Azure != nil {
+	if c.Platform.GCP != nil || c.Platform.Azure != nil || c.Platform.IBMCloud != nil {
 		nameErr = validate.ClusterName1035(c.ObjectMeta.Name)
 	}
 	if c.Platform.VSphere != nil || c.Platform.BareMetal != nil || c.Platform.OpenStack != nil || c.Platform.Nutanix != nil {
@@ -748,7 +748,9 @@ func validatePlatform(platform *types.Platform, usingAgentMethod bool, fldPath *
 		validate(gcp.Name, platform.GCP, func(f *field.Path) field.ErrorList { return gcpvalidation.ValidatePlatform(platform.GCP, f, c) })
 	}
 	if platform.IBMCloud != nil {
-		validate(ibmcloud.Name, platform.IBMCloud, func(f *field.Path) field.ErrorList { return ibmcloudvalidation.ValidatePlatform(platform.IBMCloud, f) })
+		validate(ibmcloud.Name, platform.IBMCloud, func(f *field.Path) field.ErrorList {
+			return ibmcloudvalidation.ValidatePlatform(platform.IBMCloud, f, c)
+		})
 	}
 	if platform.Libvirt != nil {
 		validate(libvirt.Name, platform.Libvirt, func(f *field.Path) field.ErrorList { return libvirtvalidation.ValidatePlatform(platform.Libvirt, f) })
@@ -1031,6 +1031,18 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `^\Qplatform.ibmcloud.region: Required value: region must be specified\E$`,
 		},
+		{
+			name: "invalid ibmcloud cluster name",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					IBMCloud: validIBMCloudPlatform(),
+				}
+				c.ObjectMeta.Name = "1-invalid-cluster"
+				return c
+			}(),
+			expectedError: `^metadata\.name: Invalid value: "1-invalid-cluster": cluster name must begin with a lower-case letter$`,
+		},
 		{
 			name: "valid powervs platform",
 			installConfig: func() *types.InstallConfig {